@@ -0,0 +1,27 @@
+package amqp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeMessage marshals a typed message into the JSON body published to a
+// queue, so callers stop hand-rolling json.Marshal around SendMessage
+func EncodeMessage[T any](msg T) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %T message: %w", msg, err)
+	}
+	return data, nil
+}
+
+// DecodeMessage unmarshals a delivery body into the typed message a handler
+// expects. Handlers should treat an error as a permanent failure (nack
+// without requeue) since a malformed body will never parse on redelivery.
+func DecodeMessage[T any](body []byte) (T, error) {
+	var msg T
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return msg, fmt.Errorf("decoding %T message: %w", msg, err)
+	}
+	return msg, nil
+}
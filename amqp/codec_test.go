@@ -0,0 +1,34 @@
+package amqp
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEncodeDecodeMessage(t *testing.T) {
+	original := APIDispatcherMessage{
+		AnalysisId:     uuid.New(),
+		ProjectId:      uuid.New(),
+		OrganizationId: uuid.New(),
+	}
+
+	body, err := EncodeMessage(original)
+	if err != nil {
+		t.Fatalf("EncodeMessage returned error: %v", err)
+	}
+
+	decoded, err := DecodeMessage[APIDispatcherMessage](body)
+	if err != nil {
+		t.Fatalf("DecodeMessage returned error: %v", err)
+	}
+	if decoded.AnalysisId != original.AnalysisId || decoded.OrganizationId != original.OrganizationId {
+		t.Errorf("round trip mismatch: %+v != %+v", decoded, original)
+	}
+}
+
+func TestDecodeMessageMalformedBody(t *testing.T) {
+	if _, err := DecodeMessage[APIDispatcherMessage]([]byte("not json")); err == nil {
+		t.Errorf("expected error for malformed body")
+	}
+}
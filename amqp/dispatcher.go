@@ -60,6 +60,32 @@ type DownloaderDispatcherMessage struct {
 	DetectedLanguages   []string  `json:"detected_languages"`
 	PrimaryLanguage     string    `json:"primary_language"`
 	DetectionConfidence float64   `json:"detection_confidence"`
+	// Status reports whether the download succeeded ("success"/"failure") so
+	// the dispatcher does not proceed on missing source; Error carries the
+	// failure reason when Status is "failure"
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Dispatcher -> API
+// Published when an analysis stage completes, so observers can push real-time
+// progress to the frontend instead of polling
+type StageCompletedMessage struct {
+	AnalysisId     uuid.UUID `json:"analysis_id"`
+	OrganizationId uuid.UUID `json:"organization_id"`
+	Stage          int       `json:"stage"`
+	TotalStages    int       `json:"total_stages"`
+	Status         string    `json:"status"`
+	CompletedOn    string    `json:"completed_on"`
+}
+
+// API -> Dispatcher / Plugins
+// Requests cancellation of an in-progress analysis. Plugins check for a
+// cancellation before starting expensive work.
+type AnalysisCancellationMessage struct {
+	AnalysisId     uuid.UUID `json:"analysis_id"`
+	OrganizationId uuid.UUID `json:"organization_id"`
+	RequestedBy    uuid.UUID `json:"requested_by"`
 }
 
 type Config struct {
@@ -0,0 +1,42 @@
+package amqp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageEnvelope wraps a message payload with the metadata consumers need
+// to identify the schema and trace a message back to its origin. Producers
+// publish it as application/json; the MessageId and Timestamp fields mirror
+// the AMQP properties of the same name.
+type MessageEnvelope struct {
+	MessageId   uuid.UUID       `json:"message_id"`
+	Timestamp   time.Time       `json:"timestamp"`
+	Producer    string          `json:"producer"`     // Producing service name, e.g. "dispatcher"
+	MessageType string          `json:"message_type"` // Schema identifier, e.g. "api_dispatcher_message"
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// NewMessageEnvelope wraps a payload in an envelope with a fresh message id
+// and the current timestamp
+func NewMessageEnvelope(producer string, messageType string, payload any) (MessageEnvelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return MessageEnvelope{}, err
+	}
+	return MessageEnvelope{
+		MessageId:   uuid.New(),
+		Timestamp:   time.Now().UTC(),
+		Producer:    producer,
+		MessageType: messageType,
+		Payload:     data,
+	}, nil
+}
+
+// DecodePayload unmarshals the envelope's payload into out, so consumers can
+// read the typed message after inspecting the metadata
+func (e *MessageEnvelope) DecodePayload(out any) error {
+	return json.Unmarshal(e.Payload, out)
+}
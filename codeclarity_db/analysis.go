@@ -1,6 +1,11 @@
 package codeclarity
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -17,7 +22,25 @@ type Analysis struct {
 	Status         AnalysisStatus
 	Commit         string `bun:"commit_hash"`
 	Branch         string
-	// Results       []*result.Result `bun:"rel:has-many,join:id=analysisId"`
+	// Deadline bounds the total runtime of the analysis; plugins fail their
+	// step as TIMEOUT once it has passed. The zero value means no deadline.
+	Deadline time.Time `bun:"deadline,nullzero"`
+	// Version implements optimistic locking: updates must carry
+	// WHERE version = ? and increment it, so two plugins finishing the same
+	// stage concurrently cannot silently overwrite each other's step result
+	// when the whole Steps document is replaced
+	Version int       `bun:"version,default:0"`
+	Results []*Result `bun:"rel:has-many,join:id=analysisId"`
+}
+
+// LoadResults eager-loads the analysis results through the bun relation in a
+// single round trip instead of the separate manual query services used to do
+func (a *Analysis) LoadResults(ctx context.Context, db *bun.DB) error {
+	return db.NewSelect().
+		Model(a).
+		WherePK().
+		Relation("Results").
+		Scan(ctx)
 }
 
 type Step struct {
@@ -39,4 +62,163 @@ const (
 	FAILURE     AnalysisStatus = "failure"
 	COMPLETED   AnalysisStatus = "completed"
 	STARTED     AnalysisStatus = "started"
+	CANCELLED   AnalysisStatus = "cancelled"
+	TIMEOUT     AnalysisStatus = "timeout"
 )
+
+// ErrStageOutOfRange is returned when a stage index does not exist in the
+// analysis Steps matrix
+var ErrStageOutOfRange = errors.New("analysis stage out of range")
+
+// ErrStepNotFound is returned when a stage does not contain a step with the
+// requested name
+var ErrStepNotFound = errors.New("analysis step not found")
+
+// StepsAtStage returns the steps of the given stage with bounds checking,
+// returning a descriptive error instead of panicking on a corrupt or
+// concurrently-modified analysis document (e.g. a Stage reset by a
+// cancellation while an update is in flight)
+func (a *Analysis) StepsAtStage(stage int) ([]Step, error) {
+	if stage < 0 || stage >= len(a.Steps) {
+		return nil, fmt.Errorf("%w: stage %d, analysis %s has %d stages", ErrStageOutOfRange, stage, a.Id, len(a.Steps))
+	}
+	return a.Steps[stage], nil
+}
+
+// GetStep returns a pointer to the named step in the given stage, so
+// plugins can read or mutate their step without reimplementing the index
+// math of the update loop
+func (a *Analysis) GetStep(stage int, name string) (*Step, error) {
+	if _, err := a.StepsAtStage(stage); err != nil {
+		return nil, err
+	}
+	for i := range a.Steps[stage] {
+		if a.Steps[stage][i].Name == name {
+			return &a.Steps[stage][i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: step %q in stage %d", ErrStepNotFound, name, stage)
+}
+
+// SetStepResult records the outcome of a step: its result document, status
+// and start/end timestamps. Illegal status transitions (e.g. resurrecting a
+// failed step) are refused.
+func (a *Analysis) SetStepResult(stage int, name string, result map[string]any, status AnalysisStatus, started time.Time, ended time.Time) error {
+	step, err := a.GetStep(stage, name)
+	if err != nil {
+		return err
+	}
+	if !CanTransition(step.Status, status) {
+		return fmt.Errorf("illegal status transition %q -> %q for step %q", step.Status, status, name)
+	}
+	step.Result = result
+	step.Status = status
+	if !started.IsZero() {
+		step.Started_on = started.Format(time.RFC3339)
+	}
+	if !ended.IsZero() {
+		step.Ended_on = ended.Format(time.RFC3339)
+	}
+	return nil
+}
+
+// CurrentStageSteps returns the steps of the stage the analysis is
+// currently in, or nil when the Stage index is out of bounds
+func (a *Analysis) CurrentStageSteps() []Step {
+	steps, err := a.StepsAtStage(a.Stage)
+	if err != nil {
+		return nil
+	}
+	return steps
+}
+
+// AllStepsCompleted reports whether every step of the given stage has
+// reached a terminal status, meaning the dispatcher can advance to the next
+// stage
+func (a *Analysis) AllStepsCompleted(stage int) bool {
+	steps, err := a.StepsAtStage(stage)
+	if err != nil {
+		return false
+	}
+	for _, step := range steps {
+		if !step.Status.IsTerminal() {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateSteps checks that the Steps matrix is well-formed: the current
+// Stage is within bounds, every step has a name and no stage contains two
+// steps with the same name. A malformed matrix would otherwise panic or
+// misbehave when plugins index Steps[Stage].
+func (a *Analysis) ValidateSteps() error {
+	if a.Stage < 0 || a.Stage >= len(a.Steps) {
+		return fmt.Errorf("stage %d out of range: analysis has %d stages", a.Stage, len(a.Steps))
+	}
+	for stage, steps := range a.Steps {
+		names := make(map[string]bool, len(steps))
+		for _, step := range steps {
+			if step.Name == "" {
+				return fmt.Errorf("stage %d contains a step without a name", stage)
+			}
+			if names[step.Name] {
+				return fmt.Errorf("stage %d contains duplicate step %q", stage, step.Name)
+			}
+			names[step.Name] = true
+		}
+	}
+	return nil
+}
+
+// CloneForRerun returns a copy of the analysis ready to be run again: the
+// analyzer, project and config are kept while the id, status, stage and all
+// step results are reset. Re-runs built by hand have carried over stale
+// results before; this is the canonical way to create one.
+func (a *Analysis) CloneForRerun() Analysis {
+	clone := Analysis{
+		AnalyzerId:     a.AnalyzerId,
+		OrganizationId: a.OrganizationId,
+		ProjectId:      a.ProjectId,
+		Config:         a.Config,
+		Status:         STARTED,
+		Commit:         a.Commit,
+		Branch:         a.Branch,
+	}
+
+	clone.Steps = make([][]Step, len(a.Steps))
+	for stage, steps := range a.Steps {
+		clone.Steps[stage] = make([]Step, len(steps))
+		for i, step := range steps {
+			clone.Steps[stage][i] = Step{
+				Name:    step.Name,
+				Version: step.Version,
+				Config:  step.Config,
+			}
+		}
+	}
+	return clone
+}
+
+// DeadlineExceeded reports whether the analysis has a deadline and it has
+// passed. Plugins consult this before starting work and mark their step as
+// TIMEOUT instead of running past the allowed time.
+func (a *Analysis) DeadlineExceeded(now time.Time) bool {
+	return !a.Deadline.IsZero() && now.After(a.Deadline)
+}
+
+// Cancel marks the analysis as cancelled and cancels every step that has not
+// reached a terminal state yet, so a runaway analysis can be stopped before
+// the remaining stages run
+func (a *Analysis) Cancel() {
+	a.Status = CANCELLED
+	for stage := range a.Steps {
+		for i := range a.Steps[stage] {
+			// Steps that already reached a terminal state (including
+			// TIMEOUT) keep their outcome
+			if !a.Steps[stage][i].Status.IsTerminal() {
+				a.Steps[stage][i].Status = CANCELLED
+			}
+		}
+	}
+}
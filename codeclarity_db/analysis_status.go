@@ -0,0 +1,92 @@
+package codeclarity
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// validStatuses lists every status value an analysis or step may carry
+var validStatuses = map[AnalysisStatus]bool{
+	SUCCESS:     true,
+	UPDATING_DB: true,
+	ONGOING:     true,
+	FAILURE:     true,
+	COMPLETED:   true,
+	STARTED:     true,
+	CANCELLED:   true,
+	TIMEOUT:     true,
+}
+
+// statusTransitions encodes the legal lifecycle of an analysis status;
+// terminal statuses have no outgoing transitions. The zero value is the
+// pending state steps carry before any plugin picks them up.
+var statusTransitions = map[AnalysisStatus][]AnalysisStatus{
+	"":          {STARTED, ONGOING, FAILURE, CANCELLED, TIMEOUT},
+	STARTED:     {ONGOING, FAILURE, CANCELLED, TIMEOUT},
+	ONGOING:     {UPDATING_DB, SUCCESS, FAILURE, COMPLETED, CANCELLED, TIMEOUT},
+	UPDATING_DB: {SUCCESS, FAILURE, COMPLETED, CANCELLED, TIMEOUT},
+}
+
+// ParseAnalysisStatus converts a raw string into an AnalysisStatus,
+// rejecting values that are not one of the defined constants so typos like
+// "Succeeded" or "done" are caught at the boundary instead of breaking
+// downstream comparisons. The empty string is accepted as the zero value:
+// steps of a pending analysis carry no status until a plugin picks them up,
+// and their documents must keep round-tripping through JSON.
+func ParseAnalysisStatus(value string) (AnalysisStatus, error) {
+	if value == "" {
+		return "", nil
+	}
+	status := AnalysisStatus(value)
+	if !validStatuses[status] {
+		return "", fmt.Errorf("invalid analysis status %q", value)
+	}
+	return status, nil
+}
+
+// IsValid reports whether the status is one of the defined constants
+func (s AnalysisStatus) IsValid() bool {
+	return validStatuses[s]
+}
+
+// IsTerminal reports whether the status is an end state that no further
+// update may leave (success, failure, completed, cancelled or timeout)
+func (s AnalysisStatus) IsTerminal() bool {
+	switch s {
+	case SUCCESS, FAILURE, COMPLETED, CANCELLED, TIMEOUT:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnmarshalJSON rejects invalid status values while decoding Analysis
+// documents; the empty string decodes to the zero value so pending steps
+// round-trip
+func (s *AnalysisStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	status, err := ParseAnalysisStatus(value)
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+// CanTransition reports whether moving from one status to the other is a
+// legal lifecycle transition; updateAnalysisStep uses it to refuse illegal
+// updates (e.g. resurrecting a failed step)
+func CanTransition(from AnalysisStatus, to AnalysisStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package codeclarity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAnalysisStatus(t *testing.T) {
+	if status, err := ParseAnalysisStatus("success"); err != nil || status != SUCCESS {
+		t.Errorf("ParseAnalysisStatus(success) = %q, %v", status, err)
+	}
+	for _, invalid := range []string{"Succeeded", "done", "SUCCESS"} {
+		if _, err := ParseAnalysisStatus(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+	// The zero value is legal: steps of a pending analysis have no status yet
+	if status, err := ParseAnalysisStatus(""); err != nil || status != AnalysisStatus("") {
+		t.Errorf("ParseAnalysisStatus(\"\") = %q, %v", status, err)
+	}
+}
+
+func TestAnalysisStatusIsTerminal(t *testing.T) {
+	for _, terminal := range []AnalysisStatus{SUCCESS, FAILURE, COMPLETED, CANCELLED, TIMEOUT} {
+		if !terminal.IsTerminal() {
+			t.Errorf("%s should be terminal", terminal)
+		}
+	}
+	for _, active := range []AnalysisStatus{STARTED, ONGOING, UPDATING_DB} {
+		if active.IsTerminal() {
+			t.Errorf("%s should not be terminal", active)
+		}
+	}
+}
+
+func TestAnalysisStatusUnmarshalJSON(t *testing.T) {
+	var step Step
+	if err := json.Unmarshal([]byte(`{"Name":"js-sbom","Status":"ongoing"}`), &step); err != nil {
+		t.Fatalf("valid status rejected: %v", err)
+	}
+	if step.Status != ONGOING {
+		t.Errorf("Status = %q, want ongoing", step.Status)
+	}
+	if err := json.Unmarshal([]byte(`{"Name":"js-sbom","Status":"done"}`), &step); err == nil {
+		t.Errorf("expected error for invalid status value")
+	}
+
+	// Pending analyses marshal their steps with an empty status; the
+	// resulting document must decode again
+	if err := json.Unmarshal([]byte(`{"Name":"js-sbom","Status":""}`), &step); err != nil {
+		t.Errorf("empty status rejected: %v", err)
+	}
+	if step.Status != AnalysisStatus("") {
+		t.Errorf("Status = %q, want zero value", step.Status)
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	legal := [][2]AnalysisStatus{
+		{AnalysisStatus(""), STARTED},
+		{AnalysisStatus(""), ONGOING},
+		{STARTED, ONGOING},
+		{ONGOING, UPDATING_DB},
+		{UPDATING_DB, SUCCESS},
+		{ONGOING, FAILURE},
+		{STARTED, CANCELLED},
+	}
+	for _, transition := range legal {
+		if !CanTransition(transition[0], transition[1]) {
+			t.Errorf("expected %s -> %s to be legal", transition[0], transition[1])
+		}
+	}
+
+	illegal := [][2]AnalysisStatus{
+		{AnalysisStatus(""), SUCCESS},
+		{SUCCESS, ONGOING},
+		{FAILURE, SUCCESS},
+		{UPDATING_DB, STARTED},
+		{CANCELLED, ONGOING},
+	}
+	for _, transition := range illegal {
+		if CanTransition(transition[0], transition[1]) {
+			t.Errorf("expected %s -> %s to be illegal", transition[0], transition[1])
+		}
+	}
+}
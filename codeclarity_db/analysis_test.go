@@ -0,0 +1,115 @@
+package codeclarity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func multiStageAnalysis() Analysis {
+	return Analysis{
+		Stage: 0,
+		Steps: [][]Step{
+			{
+				{Name: "js-sbom", Status: ONGOING},
+				{Name: "php-sbom", Status: ONGOING},
+			},
+			{
+				{Name: "vuln-finder", Status: STARTED},
+			},
+		},
+	}
+}
+
+func TestGetStep(t *testing.T) {
+	analysis := multiStageAnalysis()
+
+	step, err := analysis.GetStep(0, "php-sbom")
+	if err != nil {
+		t.Fatalf("GetStep returned error: %v", err)
+	}
+	if step.Name != "php-sbom" {
+		t.Errorf("unexpected step: %+v", step)
+	}
+
+	if _, err := analysis.GetStep(0, "missing"); !errors.Is(err, ErrStepNotFound) {
+		t.Errorf("expected ErrStepNotFound, got %v", err)
+	}
+	if _, err := analysis.GetStep(5, "js-sbom"); !errors.Is(err, ErrStageOutOfRange) {
+		t.Errorf("expected ErrStageOutOfRange, got %v", err)
+	}
+}
+
+func TestSetStepResult(t *testing.T) {
+	analysis := multiStageAnalysis()
+	started := time.Now().Add(-time.Minute)
+	ended := time.Now()
+
+	err := analysis.SetStepResult(0, "js-sbom", map[string]any{"id": "abc"}, SUCCESS, started, ended)
+	if err != nil {
+		t.Fatalf("SetStepResult returned error: %v", err)
+	}
+
+	step, _ := analysis.GetStep(0, "js-sbom")
+	if step.Status != SUCCESS || step.Result["id"] != "abc" {
+		t.Errorf("step not updated: %+v", step)
+	}
+	if step.Started_on == "" || step.Ended_on == "" {
+		t.Errorf("timestamps not recorded: %+v", step)
+	}
+
+	// The other step of the stage is untouched
+	other, _ := analysis.GetStep(0, "php-sbom")
+	if other.Status != ONGOING {
+		t.Errorf("sibling step modified: %+v", other)
+	}
+
+	// A terminal step cannot be resurrected
+	err = analysis.SetStepResult(0, "js-sbom", nil, ONGOING, started, ended)
+	if err == nil {
+		t.Errorf("expected illegal transition error")
+	}
+}
+
+func TestSetStepResultOnPristineAnalysis(t *testing.T) {
+	// Steps of a freshly created analysis (DefaultAnalyzer, CloneForRerun)
+	// carry the zero-value status; picking one up must be legal
+	analysis := Analysis{
+		Stage: 0,
+		Steps: [][]Step{
+			{{Name: "js-sbom"}},
+		},
+	}
+
+	if err := analysis.SetStepResult(0, "js-sbom", nil, ONGOING, time.Now(), time.Time{}); err != nil {
+		t.Fatalf("SetStepResult on a pristine step returned error: %v", err)
+	}
+	step, _ := analysis.GetStep(0, "js-sbom")
+	if step.Status != ONGOING {
+		t.Errorf("Status = %q, want ongoing", step.Status)
+	}
+}
+
+func TestCurrentStageStepsAndCompletion(t *testing.T) {
+	analysis := multiStageAnalysis()
+
+	if steps := analysis.CurrentStageSteps(); len(steps) != 2 {
+		t.Errorf("expected 2 steps in current stage, got %d", len(steps))
+	}
+
+	if analysis.AllStepsCompleted(0) {
+		t.Errorf("stage 0 should not be complete yet")
+	}
+	if err := analysis.SetStepResult(0, "js-sbom", nil, SUCCESS, time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := analysis.SetStepResult(0, "php-sbom", nil, FAILURE, time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if !analysis.AllStepsCompleted(0) {
+		t.Errorf("stage 0 should be complete after both steps finished")
+	}
+	if analysis.AllStepsCompleted(1) {
+		t.Errorf("stage 1 should not be complete")
+	}
+}
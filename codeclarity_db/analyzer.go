@@ -1,6 +1,11 @@
 package codeclarity
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	plugin "github.com/CodeClarityCE/utility-types/plugin_db"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -15,3 +20,88 @@ type Analyzer struct {
 	OrganizationId string `bun:"organizationId"`
 	CreatedById    string `bun:"createdById"`
 }
+
+// Validate checks that every step of the analyzer references an available
+// plugin and that a step's dependencies are scheduled in an earlier stage,
+// so a broken pipeline is rejected before it is stored
+func (a *Analyzer) Validate(availablePlugins []plugin.Plugin) error {
+	pluginsByName := make(map[string]plugin.Plugin, len(availablePlugins))
+	for _, p := range availablePlugins {
+		pluginsByName[p.Name] = p
+	}
+
+	seenInEarlierStage := make(map[string]bool)
+	for stage, steps := range a.Steps {
+		for _, step := range steps {
+			p, exists := pluginsByName[step.Name]
+			if !exists {
+				return fmt.Errorf("stage %d references unknown plugin %q", stage, step.Name)
+			}
+			for _, dependency := range p.DependsOn {
+				if !seenInEarlierStage[dependency] {
+					return fmt.Errorf("stage %d step %q depends on %q which is not scheduled in an earlier stage", stage, step.Name, dependency)
+				}
+			}
+		}
+		for _, step := range steps {
+			seenInEarlierStage[step.Name] = true
+		}
+	}
+	return nil
+}
+
+// DefaultAnalyzer builds an analyzer whose stages follow the plugins'
+// dependency order: plugins without dependencies run first and each later
+// stage only contains plugins whose dependencies completed earlier. Creating
+// an analyzer no longer requires hand-building the step matrix. Plugins whose
+// dependencies cannot be satisfied (missing or cyclic) are reported as an
+// error instead of being scheduled into a pipeline Validate would reject.
+func DefaultAnalyzer(orgId string, plugins []plugin.Plugin) (Analyzer, error) {
+	var steps [][]Step
+	scheduled := make(map[string]bool)
+	remaining := make([]plugin.Plugin, len(plugins))
+	copy(remaining, plugins)
+
+	for len(remaining) > 0 {
+		var stage []Step
+		var next []plugin.Plugin
+		for _, p := range remaining {
+			ready := true
+			for _, dependency := range p.DependsOn {
+				if !scheduled[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				stage = append(stage, Step{
+					Name:    p.Name,
+					Version: p.Version,
+					Config:  p.Config,
+				})
+			} else {
+				next = append(next, p)
+			}
+		}
+		if len(stage) == 0 {
+			names := make([]string, len(next))
+			for i, p := range next {
+				names[i] = p.Name
+			}
+			return Analyzer{}, fmt.Errorf("plugins with unsatisfiable dependencies cannot be scheduled: %s", strings.Join(names, ", "))
+		}
+		for _, step := range stage {
+			scheduled[step.Name] = true
+		}
+		steps = append(steps, stage)
+		remaining = next
+	}
+
+	return Analyzer{
+		Name:           "default",
+		Description:    "Default analysis pipeline",
+		CreatedOn:      time.Now().UTC().Format(time.RFC3339),
+		Steps:          steps,
+		OrganizationId: orgId,
+	}, nil
+}
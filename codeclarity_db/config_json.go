@@ -0,0 +1,29 @@
+package codeclarity
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// CanonicalConfigJSON serializes an analysis config into a deterministic
+// JSON form: keys sorted at every nesting level and values normalized
+// through a JSON round trip. Two analyses with the same config always
+// produce identical bytes, which makes the output usable as part of a
+// commit+config cache key. Go map iteration order would otherwise make a
+// naive comparison unreliable.
+func CanonicalConfigJSON(config map[string]any) ([]byte, error) {
+	// Round-trip through JSON so all nested values take their canonical
+	// JSON representation (e.g. ints and floats unify to float64)
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	// encoding/json marshals map keys in sorted order at every level
+	return json.Marshal(normalized)
+}
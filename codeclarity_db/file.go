@@ -1,8 +1,12 @@
 package codeclarity
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/CodeClarityCE/utility-types/ecosystem"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -18,3 +22,40 @@ type File struct {
 	AddedById     uuid.UUID `bun:"addedById"`
 	AddedBy       User      `bun:"rel:belongs-to,join:'addedById'=id"`
 }
+
+// validFileTypes lists the file types the downloader is allowed to store
+var validFileTypes = map[string]bool{
+	"sources":  true,
+	"manifest": true,
+	"lockfile": true,
+	"archive":  true,
+}
+
+// Extension returns the lowercased filename extension without the leading
+// dot, or an empty string when the file has none
+func (f *File) Extension() string {
+	ext := filepath.Ext(f.Name)
+	if ext == "" {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(ext, "."))
+}
+
+// IsManifest checks the filename against the manifest and lockfile names
+// known to the ecosystem mapper, so the dispatcher can decide which SBOM
+// plugins to schedule for an uploaded file
+func (f *File) IsManifest() bool {
+	_, isManifest := ecosystem.NewEcosystemMapper().IsManifestFile(filepath.Base(f.Name))
+	return isManifest
+}
+
+// Validate checks that the file has a name and a known type
+func (f *File) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("file name is required")
+	}
+	if !validFileTypes[f.Type] {
+		return fmt.Errorf("invalid file type: %s", f.Type)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package codeclarity
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -16,3 +18,70 @@ type Integration struct {
 	AddedBy         string    `bun:"ownerId"`
 	ServiceDomain   string    `bun:"service_domain"`
 }
+
+type IntegrationType string
+
+const (
+	GITHUB    IntegrationType = "github"
+	GITLAB    IntegrationType = "gitlab"
+	BITBUCKET IntegrationType = "bitbucket"
+)
+
+// IntegrationCapabilities describes what an integration type supports, so
+// services can discover provider-specific behavior instead of switching on
+// raw strings
+type IntegrationCapabilities struct {
+	SupportsWebhooks   bool
+	SupportsPRComments bool
+	DefaultDomain      string
+	// APIBasePath is appended to the service domain to reach the REST API
+	APIBasePath string
+}
+
+var integrationCapabilities = map[IntegrationType]IntegrationCapabilities{
+	GITHUB: {
+		SupportsWebhooks:   true,
+		SupportsPRComments: true,
+		DefaultDomain:      "api.github.com",
+		APIBasePath:        "",
+	},
+	GITLAB: {
+		SupportsWebhooks:   true,
+		SupportsPRComments: true,
+		DefaultDomain:      "gitlab.com",
+		APIBasePath:        "/api/v4",
+	},
+	BITBUCKET: {
+		SupportsWebhooks:   true,
+		SupportsPRComments: false,
+		DefaultDomain:      "api.bitbucket.org",
+		APIBasePath:        "/2.0",
+	},
+}
+
+// Capabilities returns the capability descriptor for an integration type
+func (t IntegrationType) Capabilities() (IntegrationCapabilities, bool) {
+	capabilities, exists := integrationCapabilities[t]
+	return capabilities, exists
+}
+
+// APIBaseURL builds the REST API base URL for the integration, using its
+// service domain when set (self-hosted instances) and the provider default
+// otherwise
+func (i *Integration) APIBaseURL() string {
+	integrationType := IntegrationType(strings.ToLower(i.IntegrationType))
+	capabilities, exists := integrationCapabilities[integrationType]
+	if !exists {
+		return ""
+	}
+	domain := i.ServiceDomain
+	if domain == "" {
+		domain = capabilities.DefaultDomain
+	}
+	return "https://" + domain + capabilities.APIBasePath
+}
+
+// Type returns the typed integration type of the record
+func (i *Integration) Type() IntegrationType {
+	return IntegrationType(strings.ToLower(i.IntegrationType))
+}
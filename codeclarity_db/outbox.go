@@ -0,0 +1,27 @@
+package codeclarity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// OutboxMessage is a transactional-outbox row: plugins insert it in the same
+// transaction as their Result write and a background relay publishes unsent
+// rows to AMQP with at-least-once semantics, marking SentOn on success. This
+// avoids losing the dispatcher notification when a publish fails after the
+// database commit.
+type OutboxMessage struct {
+	bun.BaseModel `bun:"table:outbox_message,alias:outbox"`
+	Id            uuid.UUID `bun:",pk,autoincrement,type:uuid,default:uuid_generate_v4()"`
+	Queue         string    `bun:"queue"`
+	Payload       []byte    `bun:"payload"`
+	CreatedOn     time.Time `bun:"created_on,default:current_timestamp"`
+	SentOn        time.Time `bun:"sent_on,nullzero"`
+}
+
+// Sent reports whether the relay has already published this message
+func (m *OutboxMessage) Sent() bool {
+	return !m.SentOn.IsZero()
+}
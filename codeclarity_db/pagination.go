@@ -0,0 +1,38 @@
+package codeclarity
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultPageSize is used when a caller does not specify a page size
+const DefaultPageSize = 25
+
+// ListPaginated runs a paginated list query for any model of this package and
+// returns the requested page together with the total row count, so the API's
+// list endpoints (projects, analyses, integrations, ...) share one pagination
+// implementation. Pages are 1-based; optional filters refine the query.
+func ListPaginated[T any](ctx context.Context, db *bun.DB, page int, pageSize int, filters ...func(*bun.SelectQuery) *bun.SelectQuery) ([]T, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	var items []T
+	query := db.NewSelect().Model(&items)
+	for _, filter := range filters {
+		query = filter(query)
+	}
+
+	total, err := query.
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
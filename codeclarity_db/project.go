@@ -1,6 +1,10 @@
 package codeclarity
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -14,3 +18,69 @@ type Project struct {
 	Type           string
 	Url            string
 }
+
+// validProjectTypes lists the supported project kinds
+var validProjectTypes = map[string]bool{
+	"github":    true,
+	"gitlab":    true,
+	"bitbucket": true,
+	"file":      true,
+}
+
+// Validate performs soft validation of the project: the URL must be a valid
+// git/http(s) URL and the type one of the supported kinds. Downloaders parse
+// these fields repeatedly; validating them once at creation prevents
+// inconsistent handling later.
+func (p *Project) Validate() error {
+	if p.Type != "" && !validProjectTypes[strings.ToLower(p.Type)] {
+		return fmt.Errorf("unsupported project type %q", p.Type)
+	}
+	if p.Url == "" {
+		return fmt.Errorf("project url is empty")
+	}
+	// SSH remotes like git@github.com:org/repo.git are valid too
+	if strings.HasPrefix(p.Url, "git@") {
+		if !strings.Contains(p.Url, ":") {
+			return fmt.Errorf("invalid ssh remote url %q", p.Url)
+		}
+		return nil
+	}
+	parsed, err := url.Parse(p.Url)
+	if err != nil {
+		return fmt.Errorf("invalid project url %q: %w", p.Url, err)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "git", "ssh":
+	default:
+		return fmt.Errorf("unsupported url scheme %q", parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("project url %q has no host", p.Url)
+	}
+	return nil
+}
+
+// GitProvider infers the git hosting provider (github, gitlab or bitbucket)
+// from the project URL
+func (p *Project) GitProvider() (string, bool) {
+	host := p.Url
+	if strings.HasPrefix(host, "git@") {
+		host = strings.TrimPrefix(host, "git@")
+		if idx := strings.Index(host, ":"); idx != -1 {
+			host = host[:idx]
+		}
+	} else if parsed, err := url.Parse(p.Url); err == nil {
+		host = parsed.Host
+	}
+
+	host = strings.ToLower(host)
+	switch {
+	case strings.Contains(host, "github"):
+		return "github", true
+	case strings.Contains(host, "gitlab"):
+		return "gitlab", true
+	case strings.Contains(host, "bitbucket"):
+		return "bitbucket", true
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+package codeclarity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// DecodeResultValue decodes the Result column into a typed struct
+// regardless of how the driver surfaced it: raw JSON bytes, a string, or an
+// already-decoded map. The previous Result.([]byte) assertion panicked on
+// the map representation.
+func DecodeResultValue[T any](value any) (T, error) {
+	var decoded T
+
+	var data []byte
+	switch typed := value.(type) {
+	case nil:
+		return decoded, fmt.Errorf("result value is nil")
+	case []byte:
+		data = typed
+	case string:
+		data = []byte(typed)
+	case json.RawMessage:
+		data = typed
+	default:
+		// Already-decoded representations (map[string]any etc.) round-trip
+		// through JSON into the caller's type
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return decoded, fmt.Errorf("re-encoding result value of type %T: %w", value, err)
+		}
+		data = encoded
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return decoded, fmt.Errorf("decoding result into %T: %w", decoded, err)
+	}
+	return decoded, nil
+}
+
+// FetchResultAs loads a Result row and decodes its payload into the caller's
+// type, with the analysis id and plugin in any error for context
+func FetchResultAs[T any](ctx context.Context, db *bun.DB, id uuid.UUID) (T, error) {
+	var decoded T
+
+	result := new(Result)
+	err := db.NewSelect().
+		Model(result).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return decoded, fmt.Errorf("fetching result %s: %w", id, err)
+	}
+
+	decoded, err = DecodeResultValue[T](result.Result)
+	if err != nil {
+		return decoded, fmt.Errorf("result %s (analysis %s, plugin %s): %w", id, result.AnalysisId, result.Plugin, err)
+	}
+	return decoded, nil
+}
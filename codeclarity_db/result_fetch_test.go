@@ -0,0 +1,41 @@
+package codeclarity
+
+import "testing"
+
+type fetchTestPayload struct {
+	Plugin  string `json:"plugin"`
+	Entries int    `json:"entries"`
+}
+
+func TestDecodeResultValueRepresentations(t *testing.T) {
+	expected := fetchTestPayload{Plugin: "js-sbom", Entries: 42}
+
+	cases := map[string]any{
+		"bytes":  []byte(`{"plugin":"js-sbom","entries":42}`),
+		"string": `{"plugin":"js-sbom","entries":42}`,
+		"map": map[string]any{
+			"plugin":  "js-sbom",
+			"entries": 42,
+		},
+	}
+
+	for name, value := range cases {
+		decoded, err := DecodeResultValue[fetchTestPayload](value)
+		if err != nil {
+			t.Errorf("%s: DecodeResultValue returned error: %v", name, err)
+			continue
+		}
+		if decoded != expected {
+			t.Errorf("%s: decoded = %+v, want %+v", name, decoded, expected)
+		}
+	}
+}
+
+func TestDecodeResultValueErrors(t *testing.T) {
+	if _, err := DecodeResultValue[fetchTestPayload](nil); err == nil {
+		t.Errorf("expected error for nil value")
+	}
+	if _, err := DecodeResultValue[fetchTestPayload]([]byte("not json")); err == nil {
+		t.Errorf("expected error for malformed JSON")
+	}
+}
@@ -0,0 +1,32 @@
+package codeclarity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ContentHash computes a deterministic hash of the normalized result payload.
+// The payload is round-tripped through JSON so map keys are sorted and
+// logically-equal results hash equally regardless of key ordering, making the
+// hash usable to detect identical re-runs and as an ETag.
+func (r *Result) ContentHash() (string, error) {
+	encoded, err := json.Marshal(r.Result)
+	if err != nil {
+		return "", err
+	}
+
+	// Re-decode into a generic value so structs and maps with the same
+	// content normalize to the same representation before hashing
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return "", err
+	}
+	canonical, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
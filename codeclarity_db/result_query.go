@@ -0,0 +1,78 @@
+package codeclarity
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// GetResultProjection fetches only the given top-level fields of a result's
+// JSONB payload, so API endpoints can read counts or summaries without
+// materializing the whole blob. Fields missing from the payload map to nil.
+func GetResultProjection(ctx context.Context, db *bun.DB, id uuid.UUID, fields ...string) (map[string]json.RawMessage, error) {
+	projection := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		var raw []byte
+		err := db.NewRaw("SELECT result->? FROM result WHERE id = ?", field, id).Scan(ctx, &raw)
+		if err != nil {
+			return nil, err
+		}
+		if raw == nil {
+			projection[field] = nil
+			continue
+		}
+		projection[field] = json.RawMessage(raw)
+	}
+	return projection, nil
+}
+
+// GetResultArrayLength returns the number of elements of an array field of a
+// result's JSONB payload without loading the array itself
+func GetResultArrayLength(ctx context.Context, db *bun.DB, id uuid.UUID, field string) (int, error) {
+	var length int
+	err := db.NewRaw("SELECT COALESCE(jsonb_array_length(result->?), 0) FROM result WHERE id = ?", field, id).Scan(ctx, &length)
+	if err != nil {
+		return 0, err
+	}
+	return length, nil
+}
+
+// StreamResultArray iterates over an array field of a result's JSONB payload
+// in chunks of chunkSize elements, invoking fn for each chunk. The full array
+// is never held in memory, which keeps large results (e.g. SBOMs with
+// thousands of dependencies) from exhausting memory. Returning an error from
+// fn stops the iteration.
+func StreamResultArray(ctx context.Context, db *bun.DB, id uuid.UUID, field string, chunkSize int, fn func(chunk []json.RawMessage) error) error {
+	if chunkSize <= 0 {
+		chunkSize = 100
+	}
+
+	offset := 0
+	for {
+		var rows []string
+		err := db.NewRaw(
+			"SELECT jsonb_array_elements(result->?) FROM result WHERE id = ? OFFSET ? LIMIT ?",
+			field, id, offset, chunkSize,
+		).Scan(ctx, &rows)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		chunk := make([]json.RawMessage, 0, len(rows))
+		for _, row := range rows {
+			chunk = append(chunk, json.RawMessage(row))
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+		if len(rows) < chunkSize {
+			return nil
+		}
+		offset += chunkSize
+	}
+}
@@ -0,0 +1,92 @@
+package codeclarity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ResultRepository bundles the common queries against the Result table so
+// plugins stop hand-rolling the same NewSelect calls
+type ResultRepository struct {
+	// DeleteBatchSize bounds how many rows a single DELETE of
+	// DeleteOlderThan removes, keeping lock times short on large tables
+	DeleteBatchSize int
+}
+
+// NewResultRepository creates a repository with the default batch size
+func NewResultRepository() *ResultRepository {
+	return &ResultRepository{DeleteBatchSize: 1000}
+}
+
+// GetLatestByAnalysisAndPlugin returns the most recent result a plugin
+// produced for an analysis
+func (r *ResultRepository) GetLatestByAnalysisAndPlugin(ctx context.Context, db *bun.DB, analysisId uuid.UUID, plugin string) (*Result, error) {
+	result := new(Result)
+	err := db.NewSelect().
+		Model(result).
+		Where("\"analysisId\" = ?", analysisId).
+		Where("plugin = ?", plugin).
+		Order("created_on DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListByAnalysis returns all results of an analysis
+func (r *ResultRepository) ListByAnalysis(ctx context.Context, db *bun.DB, analysisId uuid.UUID) ([]Result, error) {
+	var results []Result
+	err := db.NewSelect().
+		Model(&results).
+		Where("\"analysisId\" = ?", analysisId).
+		Order("created_on DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// DeleteOlderThan removes results created before the cutoff in batches and
+// returns the number of rows removed. With keepLatestPerAnalysis the newest
+// result of each analysis/plugin pair survives regardless of age, so a
+// finished analysis stays viewable after cleanup.
+func (r *ResultRepository) DeleteOlderThan(ctx context.Context, db *bun.DB, cutoff time.Time, keepLatestPerAnalysis bool) (int64, error) {
+	batchSize := r.DeleteBatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	var total int64
+	for {
+		batch := db.NewSelect().
+			Model((*Result)(nil)).
+			Column("id").
+			Where("created_on < ?", cutoff).
+			Limit(batchSize)
+		if keepLatestPerAnalysis {
+			batch = batch.Where(`id NOT IN (SELECT DISTINCT ON ("analysisId", plugin) id FROM result ORDER BY "analysisId", plugin, created_on DESC)`)
+		}
+
+		res, err := db.NewDelete().
+			Model((*Result)(nil)).
+			Where("id IN (?)", batch).
+			Exec(ctx)
+		if err != nil {
+			return total, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+		if affected < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
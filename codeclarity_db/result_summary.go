@@ -0,0 +1,57 @@
+package codeclarity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResultSummary is the compact shape emitted by MarshalSummary, suitable for
+// list endpoints that should not ship full result payloads
+type ResultSummary struct {
+	Id         uuid.UUID `json:"id"`
+	AnalysisId uuid.UUID `json:"analysisId"`
+	Plugin     string    `json:"plugin"`
+	CreatedOn  time.Time `json:"created_on"`
+	Result     any       `json:"result"`
+}
+
+// MarshalSummary emits a compact JSON summary of the result in which nested
+// arrays are replaced by their element counts, keeping the list and detail
+// response shapes consistent across services without shipping megabytes
+func (r *Result) MarshalSummary() ([]byte, error) {
+	encoded, err := json.Marshal(r.Result)
+	if err != nil {
+		return nil, err
+	}
+	var payload any
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(ResultSummary{
+		Id:         r.Id,
+		AnalysisId: r.AnalysisId,
+		Plugin:     r.Plugin,
+		CreatedOn:  r.CreatedOn,
+		Result:     summarizeValue(payload),
+	})
+}
+
+// summarizeValue recursively replaces arrays with {"count": n} markers while
+// keeping scalar fields and object structure intact
+func summarizeValue(value any) any {
+	switch typed := value.(type) {
+	case []any:
+		return map[string]any{"count": len(typed)}
+	case map[string]any:
+		summary := make(map[string]any, len(typed))
+		for key, nested := range typed {
+			summary[key] = summarizeValue(nested)
+		}
+		return summary
+	default:
+		return value
+	}
+}
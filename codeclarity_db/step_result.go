@@ -0,0 +1,89 @@
+package codeclarity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// MaxInlineResultSize is the size in bytes above which a result may not be
+// inlined into Step.Result and must be stored in the Result table instead.
+// Inlined megabyte-sized results bloat the analysis row and slow every
+// reload.
+const MaxInlineResultSize = 32 * 1024
+
+// StepResultRef is the small document stored in Step.Result in place of the
+// full plugin output: a reference into the Result table plus a few summary
+// fields for display
+type StepResultRef struct {
+	ResultId uuid.UUID      `json:"resultId"`
+	Summary  map[string]any `json:"summary,omitempty"`
+}
+
+// AsMap renders the reference in the map form Step.Result expects
+func (r StepResultRef) AsMap() map[string]any {
+	ref := map[string]any{"resultId": r.ResultId.String()}
+	if len(r.Summary) > 0 {
+		ref["summary"] = r.Summary
+	}
+	return ref
+}
+
+// StoreStepResult writes the full plugin payload into the Result table and
+// returns the reference to store in Step.Result. The summary should stay
+// well below MaxInlineResultSize; oversized summaries are refused.
+func StoreStepResult(ctx context.Context, db *bun.DB, analysisId uuid.UUID, plugin string, payload map[string]any, summary map[string]any) (uuid.UUID, error) {
+	if len(summary) > 0 {
+		encoded, err := json.Marshal(summary)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("marshalling summary for plugin %s: %w", plugin, err)
+		}
+		if len(encoded) > MaxInlineResultSize {
+			return uuid.Nil, fmt.Errorf("summary for plugin %s exceeds inline limit (%d > %d bytes)", plugin, len(encoded), MaxInlineResultSize)
+		}
+	}
+
+	result := Result{
+		Result:     payload,
+		AnalysisId: analysisId,
+		Plugin:     plugin,
+	}
+	if _, err := db.NewInsert().Model(&result).Exec(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("storing result for plugin %s: %w", plugin, err)
+	}
+	return result.Id, nil
+}
+
+// LoadStepResult fetches the full result referenced from a step
+func LoadStepResult(ctx context.Context, db *bun.DB, resultId uuid.UUID) (*Result, error) {
+	result := new(Result)
+	err := db.NewSelect().
+		Model(result).
+		Where("id = ?", resultId).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading result %s: %w", resultId, err)
+	}
+	return result, nil
+}
+
+// ParseStepResultRef reads a reference back out of a Step.Result document,
+// reporting whether the step stores a reference rather than an inline result
+func ParseStepResultRef(stepResult map[string]any) (StepResultRef, bool) {
+	raw, exists := stepResult["resultId"].(string)
+	if !exists {
+		return StepResultRef{}, false
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return StepResultRef{}, false
+	}
+	ref := StepResultRef{ResultId: id}
+	if summary, exists := stepResult["summary"].(map[string]any); exists {
+		ref.Summary = summary
+	}
+	return ref, true
+}
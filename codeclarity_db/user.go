@@ -8,4 +8,38 @@ import (
 type User struct {
 	bun.BaseModel `bun:"table:user,alias:user"`
 	Id            uuid.UUID `bun:",pk,autoincrement,type:uuid,default:uuid_generate_v4()"`
+	Email         string    `bun:"email"`
+	Name          string    `bun:"name"`
+	Roles         []string  `bun:"roles,array"`
+}
+
+// rolePermissions maps each role to the permissions it grants
+var rolePermissions = map[string][]string{
+	"admin": {"org:manage", "project:manage", "analysis:run", "analysis:read"},
+	"user":  {"project:manage", "analysis:run", "analysis:read"},
+	"guest": {"analysis:read"},
+}
+
+// HasRole checks whether the user carries the given role
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission checks whether any of the user's roles grants the given
+// permission, so services share one authorization check instead of each
+// defining its own user struct
+func (u *User) HasPermission(perm string) bool {
+	for _, role := range u.Roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == perm {
+				return true
+			}
+		}
+	}
+	return false
 }
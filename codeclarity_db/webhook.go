@@ -0,0 +1,97 @@
+package codeclarity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// WebhookEvent is a normalized git provider webhook, so the API can trigger
+// analyses from pushes and pull requests with the provider differences
+// handled in one place
+type WebhookEvent struct {
+	bun.BaseModel `bun:"table:webhook_event,alias:we"`
+	Id            uuid.UUID      `bun:",pk,autoincrement,type:uuid,default:uuid_generate_v4()"`
+	Provider      string         `bun:"provider" json:"provider"`
+	EventType     string         `bun:"event_type" json:"event_type"` // "push" or "pull_request"
+	Repo          string         `bun:"repo" json:"repo"`
+	Ref           string         `bun:"ref" json:"ref"`
+	Commit        string         `bun:"commit_hash" json:"commit"`
+	Payload       map[string]any `bun:"payload,type:jsonb" json:"payload"`
+	ReceivedOn    time.Time      `bun:"received_on,default:current_timestamp" json:"received_on"`
+}
+
+// NormalizeWebhook parses a raw webhook delivery from a git provider into a
+// WebhookEvent. GitHub and GitLab push and pull/merge request events are
+// supported; other providers or event kinds return an error.
+func NormalizeWebhook(provider string, headers map[string]string, body []byte) (WebhookEvent, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return WebhookEvent{}, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	event := WebhookEvent{
+		Provider:   provider,
+		Payload:    payload,
+		ReceivedOn: time.Now(),
+	}
+
+	switch provider {
+	case "github":
+		return normalizeGithubWebhook(event, headers, payload)
+	case "gitlab":
+		return normalizeGitlabWebhook(event, headers, payload)
+	}
+	return WebhookEvent{}, fmt.Errorf("unsupported webhook provider %q", provider)
+}
+
+func normalizeGithubWebhook(event WebhookEvent, headers map[string]string, payload map[string]any) (WebhookEvent, error) {
+	if repository, ok := payload["repository"].(map[string]any); ok {
+		event.Repo, _ = repository["full_name"].(string)
+	}
+
+	switch headers["X-GitHub-Event"] {
+	case "push":
+		event.EventType = "push"
+		event.Ref, _ = payload["ref"].(string)
+		event.Commit, _ = payload["after"].(string)
+	case "pull_request":
+		event.EventType = "pull_request"
+		if pullRequest, ok := payload["pull_request"].(map[string]any); ok {
+			if head, ok := pullRequest["head"].(map[string]any); ok {
+				event.Ref, _ = head["ref"].(string)
+				event.Commit, _ = head["sha"].(string)
+			}
+		}
+	default:
+		return WebhookEvent{}, fmt.Errorf("unsupported github event %q", headers["X-GitHub-Event"])
+	}
+	return event, nil
+}
+
+func normalizeGitlabWebhook(event WebhookEvent, headers map[string]string, payload map[string]any) (WebhookEvent, error) {
+	if project, ok := payload["project"].(map[string]any); ok {
+		event.Repo, _ = project["path_with_namespace"].(string)
+	}
+
+	switch headers["X-Gitlab-Event"] {
+	case "Push Hook":
+		event.EventType = "push"
+		event.Ref, _ = payload["ref"].(string)
+		event.Commit, _ = payload["checkout_sha"].(string)
+	case "Merge Request Hook":
+		event.EventType = "pull_request"
+		if attributes, ok := payload["object_attributes"].(map[string]any); ok {
+			event.Ref, _ = attributes["source_branch"].(string)
+			if lastCommit, ok := attributes["last_commit"].(map[string]any); ok {
+				event.Commit, _ = lastCommit["id"].(string)
+			}
+		}
+	default:
+		return WebhookEvent{}, fmt.Errorf("unsupported gitlab event %q", headers["X-Gitlab-Event"])
+	}
+	return event, nil
+}
@@ -95,18 +95,13 @@ func (r *HandlerRegistry) GetSupportedLanguages() []string {
 // CreateDefaultHandlers creates default handlers for supported ecosystems
 func CreateDefaultHandlers() *HandlerRegistry {
 	registry := NewHandlerRegistry()
-	ecosystemMap := GetDefaultEcosystemMap()
 
-	// Create JavaScript handler
-	if jsInfo, exists := ecosystemMap["js-sbom"]; exists {
-		jsHandler := NewBasicEcosystemHandler("JS", jsInfo)
-		registry.RegisterHandler("JS", jsHandler)
-	}
-
-	// Create PHP handler
-	if phpInfo, exists := ecosystemMap["php-sbom"]; exists {
-		phpHandler := NewBasicEcosystemHandler("PHP", phpInfo)
-		registry.RegisterHandler("PHP", phpHandler)
+	// Register a handler per entry of the default map, keyed by its language ID
+	for _, info := range GetDefaultEcosystemMap() {
+		if info.LanguageID == "" {
+			continue
+		}
+		registry.RegisterHandler(info.LanguageID, NewBasicEcosystemHandler(info.LanguageID, info))
 	}
 
 	return registry
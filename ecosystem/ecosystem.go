@@ -12,6 +12,7 @@ type EcosystemInfo struct {
 	Name                  string   `json:"name"`
 	Ecosystem             string   `json:"ecosystem"`
 	Language              string   `json:"language"`
+	LanguageID            string   `json:"languageId"`            // Short handler identifier, e.g. "JS", "PHP"
 	PackageManagerPattern string   `json:"packageManagerPattern"` // Will be converted to RegExp in frontend
 	DefaultPackageManager string   `json:"defaultPackageManager"`
 	Icon                  string   `json:"icon"`
@@ -20,6 +21,7 @@ type EcosystemInfo struct {
 	PurlType              string   `json:"purlType"`
 	RegistryUrl           string   `json:"registryUrl"`
 	Tools                 []string `json:"tools"`
+	ManifestFiles         []string `json:"manifestFiles"` // Manifest filenames, e.g. "package.json"
 }
 
 // PluginEcosystemMap maps plugin names to their ecosystem information
@@ -32,6 +34,7 @@ func GetDefaultEcosystemMap() PluginEcosystemMap {
 			Name:                  "JavaScript",
 			Ecosystem:             "npm",
 			Language:              "JavaScript",
+			LanguageID:            "JS",
 			PackageManagerPattern: `(npm|yarn|pnpm|bun)`,
 			DefaultPackageManager: "npm",
 			Icon:                  "devicon:javascript",
@@ -40,11 +43,13 @@ func GetDefaultEcosystemMap() PluginEcosystemMap {
 			PurlType:              "npm",
 			RegistryUrl:           "https://registry.npmjs.org",
 			Tools:                 []string{"npm", "yarn", "pnpm", "bun"},
+			ManifestFiles:         []string{"package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml"},
 		},
 		"php-sbom": {
 			Name:                  "PHP",
 			Ecosystem:             "packagist",
 			Language:              "PHP",
+			LanguageID:            "PHP",
 			PackageManagerPattern: `composer`,
 			DefaultPackageManager: "composer",
 			Icon:                  "devicon:php",
@@ -53,12 +58,14 @@ func GetDefaultEcosystemMap() PluginEcosystemMap {
 			PurlType:              "composer",
 			RegistryUrl:           "https://packagist.org",
 			Tools:                 []string{"composer"},
+			ManifestFiles:         []string{"composer.json", "composer.lock"},
 		},
 		// Future language support can be added here
 		"python-sbom": {
 			Name:                  "Python",
 			Ecosystem:             "pypi",
 			Language:              "Python",
+			LanguageID:            "PY",
 			PackageManagerPattern: `(pip|poetry|pipenv|conda)`,
 			DefaultPackageManager: "pip",
 			Icon:                  "devicon:python",
@@ -67,6 +74,82 @@ func GetDefaultEcosystemMap() PluginEcosystemMap {
 			PurlType:              "pypi",
 			RegistryUrl:           "https://pypi.org/simple",
 			Tools:                 []string{"pip", "poetry", "pipenv", "conda"},
+			ManifestFiles:         []string{"requirements.txt", "pyproject.toml", "poetry.lock", "Pipfile", "Pipfile.lock"},
+		},
+		"rust-sbom": {
+			Name:                  "Rust",
+			Ecosystem:             "cargo",
+			Language:              "Rust",
+			LanguageID:            "RUST",
+			PackageManagerPattern: `cargo`,
+			DefaultPackageManager: "cargo",
+			Icon:                  "devicon:rust",
+			Color:                 "#DEA584",
+			Website:               "https://crates.io",
+			PurlType:              "cargo",
+			RegistryUrl:           "https://crates.io/api/v1",
+			Tools:                 []string{"cargo"},
+			ManifestFiles:         []string{"Cargo.toml", "Cargo.lock"},
+		},
+		"maven-sbom": {
+			Name:                  "Java",
+			Ecosystem:             "maven",
+			Language:              "Java",
+			LanguageID:            "JAVA",
+			PackageManagerPattern: `(maven|mvn|gradle)`,
+			DefaultPackageManager: "maven",
+			Icon:                  "devicon:java",
+			Color:                 "#B07219",
+			Website:               "https://central.sonatype.com",
+			PurlType:              "maven",
+			RegistryUrl:           "https://repo1.maven.org/maven2",
+			Tools:                 []string{"maven", "gradle"},
+			ManifestFiles:         []string{"pom.xml", "build.gradle", "build.gradle.kts"},
+		},
+		"nuget-sbom": {
+			Name:                  "C#",
+			Ecosystem:             "nuget",
+			Language:              "C#",
+			LanguageID:            "CSHARP",
+			PackageManagerPattern: `(nuget|dotnet)`,
+			DefaultPackageManager: "nuget",
+			Icon:                  "devicon:csharp",
+			Color:                 "#178600",
+			Website:               "https://www.nuget.org",
+			PurlType:              "nuget",
+			RegistryUrl:           "https://api.nuget.org/v3/index.json",
+			Tools:                 []string{"nuget", "dotnet"},
+			ManifestFiles:         []string{"packages.config", "packages.lock.json"},
+		},
+		"go-sbom": {
+			Name:                  "Go",
+			Ecosystem:             "go",
+			Language:              "Go",
+			LanguageID:            "GO",
+			PackageManagerPattern: `go`,
+			DefaultPackageManager: "go",
+			Icon:                  "devicon:go",
+			Color:                 "#00ADD8",
+			Website:               "https://pkg.go.dev",
+			PurlType:              "golang",
+			RegistryUrl:           "https://proxy.golang.org",
+			Tools:                 []string{"go"},
+			ManifestFiles:         []string{"go.mod", "go.sum"},
+		},
+		"ruby-sbom": {
+			Name:                  "Ruby",
+			Ecosystem:             "rubygems",
+			Language:              "Ruby",
+			LanguageID:            "RUBY",
+			PackageManagerPattern: `(gem|bundler)`,
+			DefaultPackageManager: "bundler",
+			Icon:                  "devicon:ruby",
+			Color:                 "#701516",
+			Website:               "https://rubygems.org",
+			PurlType:              "gem",
+			RegistryUrl:           "https://rubygems.org/api/v1",
+			Tools:                 []string{"gem", "bundler"},
+			ManifestFiles:         []string{"Gemfile", "Gemfile.lock"},
 		},
 	}
 }
@@ -170,6 +253,89 @@ func (em *EcosystemMapper) MapPackageManagerToEcosystem(packageManager string) (
 	return "", false
 }
 
+// LanguageIDToEcosystem returns the ecosystem name for a language ID
+// (e.g. "JS" -> "npm")
+func (em *EcosystemMapper) LanguageIDToEcosystem(languageID string) (string, bool) {
+	for _, info := range em.ecosystemMap {
+		if info.LanguageID == languageID {
+			return info.Ecosystem, true
+		}
+	}
+	return "", false
+}
+
+// EcosystemToLanguageID returns the language ID for an ecosystem name
+// (e.g. "packagist" -> "PHP")
+func (em *EcosystemMapper) EcosystemToLanguageID(ecosystem string) (string, bool) {
+	for _, info := range em.ecosystemMap {
+		if info.Ecosystem == ecosystem {
+			return info.LanguageID, true
+		}
+	}
+	return "", false
+}
+
+// LanguageIDToName returns the full language name for a language ID
+// (e.g. "JS" -> "JavaScript")
+func (em *EcosystemMapper) LanguageIDToName(languageID string) (string, bool) {
+	for _, info := range em.ecosystemMap {
+		if info.LanguageID == languageID {
+			return info.Language, true
+		}
+	}
+	return "", false
+}
+
+// PluginToLanguageID returns the language ID for an SBOM plugin name
+// (e.g. "js-sbom" -> "JS")
+func (em *EcosystemMapper) PluginToLanguageID(pluginName string) (string, bool) {
+	info, exists := em.ecosystemMap[pluginName]
+	if !exists {
+		return "", false
+	}
+	return info.LanguageID, true
+}
+
+// DetectLanguages maps detected package-manager strings to the languages of
+// the matching ecosystems, deduplicated: a project using both npm and yarn
+// yields a single JavaScript entry. Package managers matching no pattern are
+// skipped.
+func (em *EcosystemMapper) DetectLanguages(packageManagers []string) []DetectedLanguage {
+	var detected []DetectedLanguage
+	seen := make(map[string]bool)
+
+	for _, packageManager := range packageManagers {
+		for _, info := range em.ecosystemMap {
+			// Anchor the pattern so e.g. "cargo" does not match the Go
+			// ecosystem's "go" pattern
+			matched, _ := regexp.MatchString("^(?:"+info.PackageManagerPattern+")$", packageManager)
+			if !matched || seen[info.Language] {
+				continue
+			}
+			seen[info.Language] = true
+			detected = append(detected, DetectedLanguage{
+				Name:  info.Language,
+				Icon:  info.Icon,
+				Color: info.Color,
+			})
+		}
+	}
+	return detected
+}
+
+// IsManifestFile checks if a filename is a known package manifest or lockfile
+// and returns the ecosystem it belongs to (e.g. "package.json" -> "npm")
+func (em *EcosystemMapper) IsManifestFile(filename string) (string, bool) {
+	for _, info := range em.ecosystemMap {
+		for _, manifest := range info.ManifestFiles {
+			if filename == manifest {
+				return info.Ecosystem, true
+			}
+		}
+	}
+	return "", false
+}
+
 // IsValidEcosystem checks if an ecosystem filter is supported
 func (em *EcosystemMapper) IsValidEcosystem(ecosystem string) bool {
 	for _, info := range em.ecosystemMap {
@@ -173,3 +173,37 @@ func TestCreateDefaultHandlers(t *testing.T) {
 		t.Error("Expected PHP handler to have PHP language")
 	}
 }
+
+func TestDetectLanguages(t *testing.T) {
+	mapper := NewEcosystemMapper()
+
+	// npm and yarn collapse to a single JavaScript entry
+	detected := mapper.DetectLanguages([]string{"npm", "yarn"})
+	if len(detected) != 1 {
+		t.Fatalf("Expected 1 detected language for npm+yarn, got %d", len(detected))
+	}
+	if detected[0].Name != "JavaScript" {
+		t.Errorf("Expected JavaScript, got %s", detected[0].Name)
+	}
+	if detected[0].Icon == "" || detected[0].Color == "" {
+		t.Error("Expected icon and color to be populated")
+	}
+
+	// Mixed PHP and Python project
+	detected = mapper.DetectLanguages([]string{"composer", "pip"})
+	if len(detected) != 2 {
+		t.Fatalf("Expected 2 detected languages for composer+pip, got %d", len(detected))
+	}
+	names := map[string]bool{}
+	for _, language := range detected {
+		names[language.Name] = true
+	}
+	if !names["PHP"] || !names["Python"] {
+		t.Errorf("Expected PHP and Python, got %v", names)
+	}
+
+	// Unknown package managers are skipped
+	if detected := mapper.DetectLanguages([]string{"make"}); len(detected) != 0 {
+		t.Errorf("Expected no detected languages for make, got %v", detected)
+	}
+}
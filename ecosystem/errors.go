@@ -0,0 +1,216 @@
+package ecosystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// EcosystemErrorType classifies ecosystem processing failures so the
+// recovery strategy can decide whether retrying makes sense
+type EcosystemErrorType string
+
+const (
+	ErrorTypeDatabase EcosystemErrorType = "database"
+	ErrorTypeNetwork  EcosystemErrorType = "network"
+	ErrorTypeParsing  EcosystemErrorType = "parsing"
+	ErrorTypeInternal EcosystemErrorType = "internal"
+)
+
+// EcosystemError is a classified error from ecosystem processing. Besides the
+// failure itself it carries the retry metadata computed by the recovery
+// strategy, so the failure result stored on the analysis step gives the
+// dispatcher enough information to schedule a retry.
+type EcosystemError struct {
+	LanguageID string             `json:"language_id,omitempty"`
+	Plugin     string             `json:"plugin,omitempty"`
+	Type       EcosystemErrorType `json:"type"`
+	Message    string             `json:"message"`
+	Err        error              `json:"-"`
+	// Attempt is the number of processing attempts made so far
+	Attempt int `json:"attempt"`
+	// NextRetryAt is when the dispatcher should retry, zero when the error
+	// is not retryable or retries are exhausted
+	NextRetryAt time.Time `json:"next_retry_at,omitempty"`
+}
+
+// Error implements the error interface
+func (e *EcosystemError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Type, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// Unwrap exposes the underlying error for errors.Is/As
+func (e *EcosystemError) Unwrap() error {
+	return e.Err
+}
+
+// NewEcosystemError creates a classified ecosystem error wrapping err
+func NewEcosystemError(errorType EcosystemErrorType, message string, err error) *EcosystemError {
+	return &EcosystemError{
+		Type:    errorType,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// ErrorRecoveryStrategy decides whether and when a failed operation should be
+// retried, using exponential backoff between attempts
+type ErrorRecoveryStrategy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	// Jitter randomizes the computed delay by up to the given fraction
+	// (0.2 means +/-20%) so many concurrently-failing messages don't retry
+	// in lockstep and stampede a shared dependency
+	Jitter float64
+}
+
+// DefaultErrorRecoveryStrategy returns the strategy used when none is
+// configured: three retries starting at one second, capped at a minute
+func DefaultErrorRecoveryStrategy() ErrorRecoveryStrategy {
+	return ErrorRecoveryStrategy{
+		MaxRetries: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   time.Minute,
+	}
+}
+
+// ShouldRetry reports whether another attempt should be made for the error.
+// Parsing errors are deterministic and never retried.
+func (s ErrorRecoveryStrategy) ShouldRetry(err *EcosystemError) bool {
+	if err == nil || err.Type == ErrorTypeParsing {
+		return false
+	}
+	return err.Attempt < s.MaxRetries
+}
+
+// GetRetryDelay computes the backoff delay before the given attempt
+// (1-based), doubling the base delay per attempt up to MaxDelay
+func (s ErrorRecoveryStrategy) GetRetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := s.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if s.MaxDelay > 0 && delay >= s.MaxDelay {
+			// Keep jittering at the cap, otherwise every client in the
+			// steady state retries after exactly MaxDelay in lockstep
+			delay = s.MaxDelay
+			break
+		}
+	}
+	return s.applyJitter(delay)
+}
+
+// applyJitter shifts the delay by a random fraction within +/-Jitter
+func (s ErrorRecoveryStrategy) applyJitter(delay time.Duration) time.Duration {
+	if s.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+	jitter := s.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	factor := 1 + jitter*(rand.Float64()*2-1)
+	jittered := time.Duration(float64(delay) * factor)
+	if jittered < 0 {
+		return 0
+	}
+	return jittered
+}
+
+// MultiError aggregates the failure of every attempt of a retried operation.
+// Seeing all attempts instead of only the last one reveals whether the cause
+// changed between tries when debugging intermittent failures.
+type MultiError struct {
+	Errors []error
+}
+
+// Error implements the error interface, listing each attempt's failure
+func (m *MultiError) Error() string {
+	if len(m.Errors) == 1 {
+		return m.Errors[0].Error()
+	}
+	parts := make([]string, 0, len(m.Errors))
+	for i, err := range m.Errors {
+		parts = append(parts, fmt.Sprintf("attempt %d: %v", i+1, err))
+	}
+	return fmt.Sprintf("%d attempts failed: %s", len(m.Errors), strings.Join(parts, "; "))
+}
+
+// Unwrap exposes the individual attempt errors for errors.Is/As
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Do executes op with retries per the strategy: each failure is classified
+// through HandleError and, while a retry is warranted, Do sleeps for the
+// computed backoff before trying again. The sleep is context-aware, so
+// cancelling the context stops the retry loop immediately. When retries are
+// exhausted the returned error is a MultiError combining every attempt's
+// failure.
+func (s ErrorRecoveryStrategy) Do(ctx context.Context, op func() error) error {
+	attempts := 0
+	var attemptErrors []error
+	for {
+		err := op()
+		if err == nil {
+			return nil
+		}
+		attemptErrors = append(attemptErrors, err)
+
+		ecoErr := asEcosystemError(err)
+		ecoErr.Attempt = attempts
+		ecoErr = s.HandleError(ecoErr)
+		attempts = ecoErr.Attempt
+
+		if ecoErr.NextRetryAt.IsZero() {
+			if len(attemptErrors) == 1 {
+				return ecoErr
+			}
+			return &MultiError{Errors: attemptErrors}
+		}
+
+		timer := time.NewTimer(time.Until(ecoErr.NextRetryAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// asEcosystemError returns err as an *EcosystemError, wrapping unclassified
+// errors as internal ones
+func asEcosystemError(err error) *EcosystemError {
+	var ecoErr *EcosystemError
+	if errors.As(err, &ecoErr) {
+		return ecoErr
+	}
+	return NewEcosystemError(ErrorTypeInternal, "operation failed", err)
+}
+
+// HandleError records a failed attempt on the error: it increments the
+// attempt counter and, when a retry is warranted, stamps NextRetryAt with the
+// computed backoff so the retry state is persisted with the error instead of
+// being thrown away
+func (s ErrorRecoveryStrategy) HandleError(err *EcosystemError) *EcosystemError {
+	if err == nil {
+		return nil
+	}
+	err.Attempt++
+	if s.ShouldRetry(err) {
+		err.NextRetryAt = time.Now().Add(s.GetRetryDelay(err.Attempt))
+	} else {
+		err.NextRetryAt = time.Time{}
+	}
+	return err
+}
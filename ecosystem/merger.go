@@ -0,0 +1,87 @@
+package ecosystem
+
+import "sort"
+
+// GenericResultMerger merges per-ecosystem result items according to the
+// configured MergeStrategy. The Key function identifies the same logical
+// item across ecosystems (e.g. a vulnerability id or a dependency name) and
+// Combine folds two occurrences of the same item into one for the union and
+// intersection strategies.
+type GenericResultMerger[T any] struct {
+	Strategy MergeStrategy
+	// PriorityOrder lists ecosystems from highest to lowest priority; it
+	// drives the priority strategy and makes the other strategies
+	// deterministic. Ecosystems not listed are processed last in
+	// alphabetical order.
+	PriorityOrder []string
+	Key           func(T) string
+	Combine       func(existing T, incoming T) T
+}
+
+// GetMergeStrategy returns the merge strategy used by this merger
+func (m *GenericResultMerger[T]) GetMergeStrategy() string {
+	return string(m.Strategy)
+}
+
+// MergeEcosystems merges the per-ecosystem item lists into one list:
+// union keeps every item, intersection keeps only items present in every
+// ecosystem, and priority lets the earlier ecosystem win when the same item
+// appears more than once
+func (m *GenericResultMerger[T]) MergeEcosystems(itemsByEcosystem map[string][]T) []T {
+	ecosystems := m.orderedEcosystems(itemsByEcosystem)
+
+	var order []string
+	merged := make(map[string]T)
+	seenIn := make(map[string]int)
+
+	for _, ecosystem := range ecosystems {
+		seenHere := make(map[string]bool)
+		for _, item := range itemsByEcosystem[ecosystem] {
+			key := m.Key(item)
+			existing, exists := merged[key]
+			if !exists {
+				merged[key] = item
+				order = append(order, key)
+			} else if m.Strategy != MergeStrategyPriority && m.Combine != nil {
+				merged[key] = m.Combine(existing, item)
+			}
+			// With the priority strategy the first ecosystem's item wins
+
+			if !seenHere[key] {
+				seenHere[key] = true
+				seenIn[key]++
+			}
+		}
+	}
+
+	result := make([]T, 0, len(order))
+	for _, key := range order {
+		if m.Strategy == MergeStrategyIntersection && seenIn[key] < len(ecosystems) {
+			continue
+		}
+		result = append(result, merged[key])
+	}
+	return result
+}
+
+// orderedEcosystems returns the map keys with the PriorityOrder entries
+// first and the remaining ecosystems sorted alphabetically
+func (m *GenericResultMerger[T]) orderedEcosystems(itemsByEcosystem map[string][]T) []string {
+	listed := make(map[string]bool, len(m.PriorityOrder))
+	var ordered []string
+	for _, ecosystem := range m.PriorityOrder {
+		if _, exists := itemsByEcosystem[ecosystem]; exists && !listed[ecosystem] {
+			ordered = append(ordered, ecosystem)
+			listed[ecosystem] = true
+		}
+	}
+
+	var remaining []string
+	for ecosystem := range itemsByEcosystem {
+		if !listed[ecosystem] {
+			remaining = append(remaining, ecosystem)
+		}
+	}
+	sort.Strings(remaining)
+	return append(ordered, remaining...)
+}
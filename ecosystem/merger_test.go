@@ -0,0 +1,73 @@
+package ecosystem
+
+import (
+	"reflect"
+	"testing"
+)
+
+type mergerItem struct {
+	Id    string
+	Count int
+}
+
+func newTestMerger(strategy MergeStrategy, priorityOrder []string) *GenericResultMerger[mergerItem] {
+	return &GenericResultMerger[mergerItem]{
+		Strategy:      strategy,
+		PriorityOrder: priorityOrder,
+		Key:           func(item mergerItem) string { return item.Id },
+		Combine: func(existing mergerItem, incoming mergerItem) mergerItem {
+			existing.Count += incoming.Count
+			return existing
+		},
+	}
+}
+
+var mergerFixture = map[string][]mergerItem{
+	"npm": {
+		{Id: "shared", Count: 1},
+		{Id: "npm-only", Count: 1},
+	},
+	"packagist": {
+		{Id: "shared", Count: 10},
+		{Id: "php-only", Count: 1},
+	},
+}
+
+func TestGenericResultMergerUnion(t *testing.T) {
+	merger := newTestMerger(MergeStrategyUnion, []string{"npm", "packagist"})
+	merged := merger.MergeEcosystems(mergerFixture)
+
+	expected := []mergerItem{
+		{Id: "shared", Count: 11}, // combined across ecosystems
+		{Id: "npm-only", Count: 1},
+		{Id: "php-only", Count: 1},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("union merge = %+v, want %+v", merged, expected)
+	}
+}
+
+func TestGenericResultMergerIntersection(t *testing.T) {
+	merger := newTestMerger(MergeStrategyIntersection, []string{"npm", "packagist"})
+	merged := merger.MergeEcosystems(mergerFixture)
+
+	expected := []mergerItem{{Id: "shared", Count: 11}}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("intersection merge = %+v, want %+v", merged, expected)
+	}
+}
+
+func TestGenericResultMergerPriority(t *testing.T) {
+	merger := newTestMerger(MergeStrategyPriority, []string{"packagist", "npm"})
+	merged := merger.MergeEcosystems(mergerFixture)
+
+	// packagist wins on the shared item; no combining happens
+	expected := []mergerItem{
+		{Id: "shared", Count: 10},
+		{Id: "php-only", Count: 1},
+		{Id: "npm-only", Count: 1},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("priority merge = %+v, want %+v", merged, expected)
+	}
+}
@@ -0,0 +1,156 @@
+package ecosystem
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// PURL represents a parsed package URL following the package-url spec,
+// e.g. "pkg:maven/org.apache/commons@1.2.3?classifier=sources#sub/path"
+type PURL struct {
+	Type       string            `json:"type"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Name       string            `json:"name"`
+	Version    string            `json:"version,omitempty"`
+	Qualifiers map[string]string `json:"qualifiers,omitempty"`
+	Subpath    string            `json:"subpath,omitempty"`
+}
+
+// ParsePURL parses a package URL string into its components with
+// percent-decoding applied, so ecosystem code can round-trip package URLs
+// instead of regex-splitting them
+func ParsePURL(s string) (PURL, error) {
+	remainder, found := strings.CutPrefix(s, "pkg:")
+	if !found {
+		return PURL{}, fmt.Errorf("missing pkg: scheme in %q", s)
+	}
+	remainder = strings.TrimPrefix(remainder, "/")
+
+	var purl PURL
+
+	if idx := strings.Index(remainder, "#"); idx != -1 {
+		subpath, err := url.PathUnescape(remainder[idx+1:])
+		if err != nil {
+			return PURL{}, fmt.Errorf("invalid subpath in %q: %w", s, err)
+		}
+		purl.Subpath = strings.Trim(subpath, "/")
+		remainder = remainder[:idx]
+	}
+
+	if idx := strings.Index(remainder, "?"); idx != -1 {
+		qualifiers, err := parseQualifiers(remainder[idx+1:])
+		if err != nil {
+			return PURL{}, fmt.Errorf("invalid qualifiers in %q: %w", s, err)
+		}
+		purl.Qualifiers = qualifiers
+		remainder = remainder[:idx]
+	}
+
+	if idx := strings.LastIndex(remainder, "@"); idx != -1 {
+		version, err := url.PathUnescape(remainder[idx+1:])
+		if err != nil {
+			return PURL{}, fmt.Errorf("invalid version in %q: %w", s, err)
+		}
+		purl.Version = version
+		remainder = remainder[:idx]
+	}
+
+	// The remainder is type/namespace.../name; the namespace may itself
+	// contain slashes (maven groups, go module paths)
+	parts := strings.Split(remainder, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[len(parts)-1] == "" {
+		return PURL{}, fmt.Errorf("missing type or name in %q", s)
+	}
+	purl.Type = strings.ToLower(parts[0])
+
+	name, err := url.PathUnescape(parts[len(parts)-1])
+	if err != nil {
+		return PURL{}, fmt.Errorf("invalid name in %q: %w", s, err)
+	}
+	purl.Name = name
+
+	if len(parts) > 2 {
+		segments := make([]string, 0, len(parts)-2)
+		for _, segment := range parts[1 : len(parts)-1] {
+			decoded, err := url.PathUnescape(segment)
+			if err != nil {
+				return PURL{}, fmt.Errorf("invalid namespace in %q: %w", s, err)
+			}
+			segments = append(segments, decoded)
+		}
+		purl.Namespace = strings.Join(segments, "/")
+	}
+
+	return purl, nil
+}
+
+// parseQualifiers parses the ?key=value&... qualifier section
+func parseQualifiers(raw string) (map[string]string, error) {
+	qualifiers := make(map[string]string)
+	for _, pair := range strings.Split(raw, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("malformed qualifier %q", pair)
+		}
+		decoded, err := url.QueryUnescape(value)
+		if err != nil {
+			return nil, err
+		}
+		qualifiers[strings.ToLower(key)] = decoded
+	}
+	if len(qualifiers) == 0 {
+		return nil, nil
+	}
+	return qualifiers, nil
+}
+
+// String renders the PURL back into its canonical string form with
+// percent-encoding and sorted qualifier keys
+func (p PURL) String() string {
+	var builder strings.Builder
+	builder.WriteString("pkg:")
+	builder.WriteString(p.Type)
+
+	if p.Namespace != "" {
+		for _, segment := range strings.Split(p.Namespace, "/") {
+			builder.WriteString("/")
+			builder.WriteString(url.PathEscape(segment))
+		}
+	}
+	builder.WriteString("/")
+	builder.WriteString(url.PathEscape(p.Name))
+
+	if p.Version != "" {
+		builder.WriteString("@")
+		builder.WriteString(url.PathEscape(p.Version))
+	}
+
+	if len(p.Qualifiers) > 0 {
+		keys := make([]string, 0, len(p.Qualifiers))
+		for key := range p.Qualifiers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			if i == 0 {
+				builder.WriteString("?")
+			} else {
+				builder.WriteString("&")
+			}
+			builder.WriteString(key)
+			builder.WriteString("=")
+			builder.WriteString(url.QueryEscape(p.Qualifiers[key]))
+		}
+	}
+
+	if p.Subpath != "" {
+		builder.WriteString("#")
+		builder.WriteString(p.Subpath)
+	}
+	return builder.String()
+}
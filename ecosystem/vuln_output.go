@@ -0,0 +1,52 @@
+package ecosystem
+
+// VulnerabilityWorkspaceInfo holds the vulnerability findings of a single
+// workspace, combined across all analyzed ecosystems
+type VulnerabilityWorkspaceInfo struct {
+	Vulnerabilities []VulnerabilityInfo `json:"Vulnerabilities"`
+}
+
+// VulnerabilityStats summarizes the findings of an analysis for display in
+// result overviews
+type VulnerabilityStats struct {
+	Total              int            `json:"total"`
+	BySeverity         map[string]int `json:"by_severity"`
+	ByEcosystem        map[string]int `json:"by_ecosystem"`
+	UniquePackageCount int            `json:"unique_package_count"`
+}
+
+// VulnerabilityOutput is the merged vulnerability result of an analysis,
+// mirroring the shape of the license merger output: findings per workspace
+// plus aggregate statistics
+type VulnerabilityOutput struct {
+	WorkspacesData map[string]VulnerabilityWorkspaceInfo `json:"workspaces_data"`
+	Stats          VulnerabilityStats                    `json:"stats"`
+}
+
+// CalculateStats computes the aggregate statistics over all workspaces:
+// totals by severity and ecosystem and the number of distinct affected
+// packages
+func (o *VulnerabilityOutput) CalculateStats() VulnerabilityStats {
+	stats := VulnerabilityStats{
+		BySeverity:  make(map[string]int),
+		ByEcosystem: make(map[string]int),
+	}
+
+	uniquePackages := make(map[string]bool)
+	for _, workspace := range o.WorkspacesData {
+		for _, vuln := range workspace.Vulnerabilities {
+			stats.Total++
+			if vuln.Severity != "" {
+				stats.BySeverity[vuln.Severity]++
+			}
+			if vuln.Ecosystem != "" {
+				stats.ByEcosystem[vuln.Ecosystem]++
+			}
+			uniquePackages[vuln.Ecosystem+":"+vuln.PackageName] = true
+		}
+	}
+	stats.UniquePackageCount = len(uniquePackages)
+
+	o.Stats = stats
+	return stats
+}
@@ -0,0 +1,37 @@
+package ecosystem
+
+import "testing"
+
+func TestCalculateStatsMultiWorkspace(t *testing.T) {
+	output := VulnerabilityOutput{
+		WorkspacesData: map[string]VulnerabilityWorkspaceInfo{
+			".": {Vulnerabilities: []VulnerabilityInfo{
+				{VulnerabilityId: "CVE-2023-0001", PackageName: "lodash", Ecosystem: "npm", Severity: "HIGH"},
+				{VulnerabilityId: "CVE-2023-0002", PackageName: "lodash", Ecosystem: "npm", Severity: "LOW"},
+				{VulnerabilityId: "CVE-2023-0003", PackageName: "symfony/http-kernel", Ecosystem: "packagist", Severity: "HIGH"},
+			}},
+			"packages/api": {Vulnerabilities: []VulnerabilityInfo{
+				{VulnerabilityId: "CVE-2023-0001", PackageName: "lodash", Ecosystem: "npm", Severity: "HIGH"},
+			}},
+		},
+	}
+
+	stats := output.CalculateStats()
+
+	if stats.Total != 4 {
+		t.Errorf("Total = %d, want 4", stats.Total)
+	}
+	if stats.BySeverity["HIGH"] != 3 || stats.BySeverity["LOW"] != 1 {
+		t.Errorf("unexpected severity breakdown: %v", stats.BySeverity)
+	}
+	if stats.ByEcosystem["npm"] != 3 || stats.ByEcosystem["packagist"] != 1 {
+		t.Errorf("unexpected ecosystem breakdown: %v", stats.ByEcosystem)
+	}
+	// lodash appears in both workspaces but counts once
+	if stats.UniquePackageCount != 2 {
+		t.Errorf("UniquePackageCount = %d, want 2", stats.UniquePackageCount)
+	}
+	if output.Stats.Total != stats.Total {
+		t.Errorf("stats not stored on the output")
+	}
+}
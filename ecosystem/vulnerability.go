@@ -0,0 +1,174 @@
+package ecosystem
+
+import "strings"
+
+// VulnerabilityInfo represents a single vulnerability finding for a package
+// in a specific ecosystem, as produced by the vulnerability mergers
+type VulnerabilityInfo struct {
+	VulnerabilityId string  `json:"vulnerability_id"`
+	PackageName     string  `json:"package_name"`
+	PackageVersion  string  `json:"package_version"`
+	Ecosystem       string  `json:"ecosystem"`
+	Severity        string  `json:"severity"`
+	SeverityScore   float64 `json:"severity_score"`
+	// Aliases lists alternative identifiers of the same vulnerability
+	// (e.g. the GHSA id of a CVE), used to deduplicate findings reported
+	// by different sources under different ids
+	Aliases []string `json:"aliases,omitempty"`
+	// FixedVersions lists the versions that fix the vulnerability
+	FixedVersions []string `json:"fixed_versions,omitempty"`
+	// Sources records which vulnerability databases reported the finding
+	// (e.g. "osv", "nvd"); after merging, a finding known to several
+	// sources lists all of them
+	Sources []string `json:"sources,omitempty"`
+	// IsDirect indicates whether the affected package is a direct dependency
+	// of the project (as opposed to a transitive one)
+	IsDirect bool `json:"is_direct"`
+	// DependencyPaths lists the paths from a root dependency to the
+	// vulnerable package, each path being a list of package names
+	DependencyPaths [][]string `json:"dependency_paths,omitempty"`
+}
+
+// VulnerabilityResultMerger merges vulnerability findings from multiple
+// sources and ecosystems into a deduplicated list
+type VulnerabilityResultMerger struct {
+	Strategy      MergeStrategy
+	PriorityOrder []string
+}
+
+// NewVulnerabilityResultMerger creates a merger with the union strategy
+func NewVulnerabilityResultMerger() *VulnerabilityResultMerger {
+	return &VulnerabilityResultMerger{Strategy: MergeStrategyUnion}
+}
+
+// GetMergeStrategy returns the merge strategy used by this merger
+func (m *VulnerabilityResultMerger) GetMergeStrategy() string {
+	return string(m.Strategy)
+}
+
+// DeduplicateByAlias collapses findings for the same package that share a
+// vulnerability id or alias, e.g. a GHSA advisory from OSV aliased to a CVE
+// that NVD reports directly. The collapsed entry keeps the highest severity,
+// the union of aliases and fixed versions, and prefers a CVE id as the
+// canonical identifier.
+func (m *VulnerabilityResultMerger) DeduplicateByAlias(vulns []VulnerabilityInfo) []VulnerabilityInfo {
+	var deduplicated []VulnerabilityInfo
+
+	for _, vuln := range vulns {
+		merged := false
+		for i := range deduplicated {
+			existing := &deduplicated[i]
+			if existing.PackageName != vuln.PackageName || existing.PackageVersion != vuln.PackageVersion {
+				continue
+			}
+			if !sharesIdentifier(existing, &vuln) {
+				continue
+			}
+			mergeVulnerability(existing, &vuln)
+			merged = true
+			break
+		}
+		if !merged {
+			deduplicated = append(deduplicated, vuln)
+		}
+	}
+	return deduplicated
+}
+
+// identifiers returns the vulnerability id and all aliases of a finding
+func identifiers(vuln *VulnerabilityInfo) []string {
+	return append([]string{vuln.VulnerabilityId}, vuln.Aliases...)
+}
+
+// sharesIdentifier checks whether two findings refer to the same
+// vulnerability through their ids or aliases
+func sharesIdentifier(a *VulnerabilityInfo, b *VulnerabilityInfo) bool {
+	for _, idA := range identifiers(a) {
+		for _, idB := range identifiers(b) {
+			if idA != "" && idA == idB {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mergeVulnerability folds the incoming finding into the existing one
+func mergeVulnerability(existing *VulnerabilityInfo, incoming *VulnerabilityInfo) {
+	canonical := existing.VulnerabilityId
+	if !strings.HasPrefix(canonical, "CVE-") && strings.HasPrefix(incoming.VulnerabilityId, "CVE-") {
+		canonical = incoming.VulnerabilityId
+	}
+
+	// Collect every identifier once, keeping the canonical id out of the
+	// alias list
+	for _, id := range append(identifiers(existing), identifiers(incoming)...) {
+		if id == "" || id == canonical || containsString(existing.Aliases, id) {
+			continue
+		}
+		existing.Aliases = append(existing.Aliases, id)
+	}
+	existing.Aliases = removeString(existing.Aliases, canonical)
+	existing.VulnerabilityId = canonical
+
+	if incoming.SeverityScore > existing.SeverityScore {
+		existing.SeverityScore = incoming.SeverityScore
+		existing.Severity = incoming.Severity
+	}
+
+	for _, version := range incoming.FixedVersions {
+		if !containsString(existing.FixedVersions, version) {
+			existing.FixedVersions = append(existing.FixedVersions, version)
+		}
+	}
+
+	for _, source := range incoming.Sources {
+		if !containsString(existing.Sources, source) {
+			existing.Sources = append(existing.Sources, source)
+		}
+	}
+
+	existing.DependencyPaths = append(existing.DependencyPaths, incoming.DependencyPaths...)
+	existing.IsDirect = existing.IsDirect || incoming.IsDirect
+}
+
+// AddSource records a vulnerability database as origin of the finding
+func (v *VulnerabilityInfo) AddSource(source string) {
+	if source == "" || containsString(v.Sources, source) {
+		return
+	}
+	v.Sources = append(v.Sources, source)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	filtered := values[:0]
+	for _, v := range values {
+		if v != value {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// SetDependencyPaths stores the dependency paths leading to the vulnerable
+// package and derives IsDirect from them: a path of length one means the
+// package is itself a root-level (direct) dependency
+func (v *VulnerabilityInfo) SetDependencyPaths(paths [][]string) {
+	v.DependencyPaths = paths
+	v.IsDirect = false
+	for _, path := range paths {
+		if len(path) == 1 {
+			v.IsDirect = true
+			return
+		}
+	}
+}
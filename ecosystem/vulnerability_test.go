@@ -0,0 +1,69 @@
+package ecosystem
+
+import "testing"
+
+func TestDeduplicateByAlias(t *testing.T) {
+	merger := NewVulnerabilityResultMerger()
+
+	vulns := []VulnerabilityInfo{
+		{
+			VulnerabilityId: "GHSA-xxxx-yyyy-zzzz",
+			Aliases:         []string{"CVE-2023-1234"},
+			PackageName:     "lodash",
+			PackageVersion:  "4.17.20",
+			Ecosystem:       "npm",
+			Severity:        "HIGH",
+			SeverityScore:   7.5,
+			FixedVersions:   []string{"4.17.21"},
+		},
+		{
+			VulnerabilityId: "CVE-2023-1234",
+			PackageName:     "lodash",
+			PackageVersion:  "4.17.20",
+			Ecosystem:       "npm",
+			Severity:        "CRITICAL",
+			SeverityScore:   9.8,
+			FixedVersions:   []string{"4.17.21", "5.0.0"},
+		},
+		{
+			VulnerabilityId: "CVE-2023-9999",
+			PackageName:     "lodash",
+			PackageVersion:  "4.17.20",
+			Ecosystem:       "npm",
+			Severity:        "LOW",
+			SeverityScore:   3.1,
+		},
+	}
+
+	deduplicated := merger.DeduplicateByAlias(vulns)
+	if len(deduplicated) != 2 {
+		t.Fatalf("expected 2 entries after deduplication, got %d", len(deduplicated))
+	}
+
+	collapsed := deduplicated[0]
+	if collapsed.VulnerabilityId != "CVE-2023-1234" {
+		t.Errorf("expected the CVE id to be canonical, got %q", collapsed.VulnerabilityId)
+	}
+	if len(collapsed.Aliases) != 1 || collapsed.Aliases[0] != "GHSA-xxxx-yyyy-zzzz" {
+		t.Errorf("expected the GHSA id as alias, got %v", collapsed.Aliases)
+	}
+	if collapsed.SeverityScore != 9.8 || collapsed.Severity != "CRITICAL" {
+		t.Errorf("expected the highest severity to win, got %s %.1f", collapsed.Severity, collapsed.SeverityScore)
+	}
+	if len(collapsed.FixedVersions) != 2 {
+		t.Errorf("expected merged fixed versions, got %v", collapsed.FixedVersions)
+	}
+}
+
+func TestDeduplicateByAliasKeepsDistinctPackages(t *testing.T) {
+	merger := NewVulnerabilityResultMerger()
+
+	vulns := []VulnerabilityInfo{
+		{VulnerabilityId: "CVE-2023-1234", PackageName: "lodash", PackageVersion: "4.17.20"},
+		{VulnerabilityId: "CVE-2023-1234", PackageName: "underscore", PackageVersion: "1.13.0"},
+	}
+
+	if got := merger.DeduplicateByAlias(vulns); len(got) != 2 {
+		t.Errorf("findings for distinct packages must not collapse, got %d entries", len(got))
+	}
+}
@@ -0,0 +1,65 @@
+package knowledge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseCPE23 parses a CPE 2.3 formatted string like
+// "cpe:2.3:a:vendor:product:1.0:*:*:*:*:*:*:*" into its attribute dictionary.
+// Unlike a plain split on ":" it honors the formatted-string escaping rules,
+// so values containing an escaped colon (e.g. a version with "\:") stay in
+// one field. Malformed strings return an error instead of panicking on a
+// short slice.
+func ParseCPE23(uri string) (CriteriaDict, error) {
+	if !strings.HasPrefix(uri, "cpe:2.3:") {
+		return CriteriaDict{}, fmt.Errorf("missing cpe:2.3: prefix in %q", uri)
+	}
+
+	fields := splitCPEFields(uri[len("cpe:2.3:"):])
+	// The 11 attributes after the prefix: part, vendor, product, version,
+	// update, edition, language, sw_edition, target_sw, target_hw, other
+	if len(fields) != 11 {
+		return CriteriaDict{}, fmt.Errorf("expected 11 CPE attributes, got %d in %q", len(fields), uri)
+	}
+
+	return CriteriaDict{
+		Part:      fields[0],
+		Vendor:    fields[1],
+		Product:   fields[2],
+		Version:   fields[3],
+		Update:    fields[4],
+		Edition:   fields[5],
+		Language:  fields[6],
+		SwEdition: fields[7],
+		TargetSw:  fields[8],
+		TargetHw:  fields[9],
+		Other:     fields[10],
+	}, nil
+}
+
+// splitCPEFields splits a CPE formatted string on unescaped colons, keeping
+// backslash escape sequences intact inside the fields
+func splitCPEFields(s string) []string {
+	var fields []string
+	var current strings.Builder
+	escaped := false
+
+	for _, char := range s {
+		switch {
+		case escaped:
+			current.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			current.WriteRune(char)
+			escaped = true
+		case char == ':':
+			fields = append(fields, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(char)
+		}
+	}
+	fields = append(fields, current.String())
+	return fields
+}
@@ -0,0 +1,214 @@
+package knowledge
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// cvss3MetricValues maps each CVSS v3 base metric to its valid abbreviations
+// and the expanded values used by the NVD JSON shape
+var cvss3MetricValues = map[string]map[string]string{
+	"AV": {"N": "NETWORK", "A": "ADJACENT_NETWORK", "L": "LOCAL", "P": "PHYSICAL"},
+	"AC": {"L": "LOW", "H": "HIGH"},
+	"PR": {"N": "NONE", "L": "LOW", "H": "HIGH"},
+	"UI": {"N": "NONE", "R": "REQUIRED"},
+	"S":  {"U": "UNCHANGED", "C": "CHANGED"},
+	"C":  {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"I":  {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"A":  {"H": "HIGH", "L": "LOW", "N": "NONE"},
+}
+
+// ParseCVSS3Vector parses a raw CVSS v3.0/v3.1 vector string like
+// "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H" into a CVSS3 struct with the
+// expanded metric values the NVD API uses. Unknown metrics or invalid
+// abbreviations return an error instead of silently defaulting, so the result
+// can be trusted for score validation.
+func ParseCVSS3Vector(vector string) (CVSS3, error) {
+	cvss := CVSS3{VectorString: vector}
+
+	switch {
+	case strings.HasPrefix(vector, "CVSS:3.1/"):
+		cvss.Version = "3.1"
+	case strings.HasPrefix(vector, "CVSS:3.0/"):
+		cvss.Version = "3.0"
+	default:
+		return CVSS3{}, fmt.Errorf("not a CVSS v3 vector: %q", vector)
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(vector[len("CVSS:3.x/"):], "/") {
+		metric, abbrev, found := strings.Cut(part, ":")
+		if !found {
+			return CVSS3{}, fmt.Errorf("malformed metric %q in vector %q", part, vector)
+		}
+		values, known := cvss3MetricValues[metric]
+		if !known {
+			// Temporal/environmental metrics are not part of the base
+			// group; accept the known base metrics only
+			return CVSS3{}, fmt.Errorf("unsupported metric %q in vector %q", metric, vector)
+		}
+		value, valid := values[abbrev]
+		if !valid {
+			return CVSS3{}, fmt.Errorf("invalid value %q for metric %q in vector %q", abbrev, metric, vector)
+		}
+		if seen[metric] {
+			return CVSS3{}, fmt.Errorf("duplicate metric %q in vector %q", metric, vector)
+		}
+		seen[metric] = true
+
+		switch metric {
+		case "AV":
+			cvss.AttackVector = value
+		case "AC":
+			cvss.AttackComplexity = value
+		case "PR":
+			cvss.PrivilegesRequired = value
+		case "UI":
+			cvss.UserInteraction = value
+		case "S":
+			cvss.Scope = value
+		case "C":
+			cvss.ConfidentialityImpact = value
+		case "I":
+			cvss.IntegrityImpact = value
+		case "A":
+			cvss.AvailabilityImpact = value
+		}
+	}
+
+	for metric := range cvss3MetricValues {
+		if !seen[metric] {
+			return CVSS3{}, fmt.Errorf("missing base metric %q in vector %q", metric, vector)
+		}
+	}
+	return cvss, nil
+}
+
+// ComputeBaseScore implements the official CVSS v3.1 base score formula,
+// including the scope-changed impact/exploitability differences and the
+// spec's round-up to one decimal. It allows validating the BaseScore shipped
+// by NVD against what the vector implies. Metrics with unknown values
+// contribute a zero weight.
+func (c *CVSS3) ComputeBaseScore() float64 {
+	confidentiality := ciaWeight(c.ConfidentialityImpact)
+	integrity := ciaWeight(c.IntegrityImpact)
+	availability := ciaWeight(c.AvailabilityImpact)
+	scopeChanged := c.Scope == "CHANGED"
+
+	issBase := 1 - (1-confidentiality)*(1-integrity)*(1-availability)
+
+	var impact float64
+	if scopeChanged {
+		impact = 7.52*(issBase-0.029) - 3.25*math.Pow(issBase-0.02, 15)
+	} else {
+		impact = 6.42 * issBase
+	}
+	if impact <= 0 {
+		return 0
+	}
+
+	exploitability := 8.22 *
+		attackVectorWeight(c.AttackVector) *
+		attackComplexityWeight(c.AttackComplexity) *
+		privilegesRequiredWeight(c.PrivilegesRequired, scopeChanged) *
+		userInteractionWeight(c.UserInteraction)
+
+	score := impact + exploitability
+	if scopeChanged {
+		score = 1.08 * score
+	}
+	if score > 10 {
+		score = 10
+	}
+	return roundUpCVSS(score)
+}
+
+// roundUpCVSS rounds up to one decimal place as defined in the CVSS v3.1
+// specification (appendix A)
+func roundUpCVSS(value float64) float64 {
+	scaled := int(math.Round(value * 100000))
+	if scaled%10000 == 0 {
+		return float64(scaled) / 100000
+	}
+	return (math.Floor(float64(scaled)/10000) + 1) / 10
+}
+
+func attackVectorWeight(value string) float64 {
+	switch value {
+	case "NETWORK":
+		return 0.85
+	case "ADJACENT_NETWORK":
+		return 0.62
+	case "LOCAL":
+		return 0.55
+	case "PHYSICAL":
+		return 0.2
+	}
+	return 0
+}
+
+func attackComplexityWeight(value string) float64 {
+	switch value {
+	case "LOW":
+		return 0.77
+	case "HIGH":
+		return 0.44
+	}
+	return 0
+}
+
+func privilegesRequiredWeight(value string, scopeChanged bool) float64 {
+	switch value {
+	case "NONE":
+		return 0.85
+	case "LOW":
+		if scopeChanged {
+			return 0.68
+		}
+		return 0.62
+	case "HIGH":
+		if scopeChanged {
+			return 0.5
+		}
+		return 0.27
+	}
+	return 0
+}
+
+func userInteractionWeight(value string) float64 {
+	switch value {
+	case "NONE":
+		return 0.85
+	case "REQUIRED":
+		return 0.62
+	}
+	return 0
+}
+
+func ciaWeight(value string) float64 {
+	switch value {
+	case "HIGH":
+		return 0.56
+	case "LOW":
+		return 0.22
+	}
+	return 0
+}
+
+// SeverityFromScore maps a CVSS base score to the qualitative severity bands
+// shared by CVSS v3 and v4
+func SeverityFromScore(score float64) string {
+	switch {
+	case score <= 0:
+		return "NONE"
+	case score < 4:
+		return "LOW"
+	case score < 7:
+		return "MEDIUM"
+	case score < 9:
+		return "HIGH"
+	default:
+		return "CRITICAL"
+	}
+}
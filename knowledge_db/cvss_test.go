@@ -0,0 +1,55 @@
+package knowledge
+
+import "testing"
+
+func TestParseCVSS3Vector(t *testing.T) {
+	cvss, err := ParseCVSS3Vector("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+	if err != nil {
+		t.Fatalf("Expected vector to parse, got error: %v", err)
+	}
+	if cvss.Version != "3.1" {
+		t.Errorf("Expected version 3.1, got %s", cvss.Version)
+	}
+	if cvss.AttackVector != "NETWORK" {
+		t.Errorf("Expected NETWORK attack vector, got %s", cvss.AttackVector)
+	}
+	if cvss.Scope != "UNCHANGED" {
+		t.Errorf("Expected UNCHANGED scope, got %s", cvss.Scope)
+	}
+	if cvss.ConfidentialityImpact != "HIGH" {
+		t.Errorf("Expected HIGH confidentiality impact, got %s", cvss.ConfidentialityImpact)
+	}
+
+	// Invalid metric values must error instead of silently defaulting
+	if _, err := ParseCVSS3Vector("CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"); err == nil {
+		t.Error("Expected an error for an invalid AV value")
+	}
+	if _, err := ParseCVSS3Vector("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H"); err == nil {
+		t.Error("Expected an error for a missing base metric")
+	}
+	if _, err := ParseCVSS3Vector("CVSS:2.0/AV:N/AC:L/Au:N/C:P/I:P/A:P"); err == nil {
+		t.Error("Expected an error for a non-v3 vector")
+	}
+}
+
+func TestComputeBaseScore(t *testing.T) {
+	cases := []struct {
+		vector string
+		score  float64
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N", 6.4},
+		{"CVSS:3.1/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", 1.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0},
+	}
+
+	for _, testCase := range cases {
+		cvss, err := ParseCVSS3Vector(testCase.vector)
+		if err != nil {
+			t.Fatalf("Expected %s to parse, got error: %v", testCase.vector, err)
+		}
+		if score := cvss.ComputeBaseScore(); score != testCase.score {
+			t.Errorf("Expected score %.1f for %s, got %.1f", testCase.score, testCase.vector, score)
+		}
+	}
+}
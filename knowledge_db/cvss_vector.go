@@ -0,0 +1,272 @@
+package knowledge
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CVSSVector is a version-independent decomposition of a CVSS vector string.
+// Only the fields of the detected version are populated: the Access* and
+// Authentication fields for v2, the Attack*/PrivilegesRequired/UserInteraction
+// and Scope fields for v3, and additionally AttackRequirements plus the
+// Sub*Impact fields for v4.
+type CVSSVector struct {
+	Version string `json:"version"`
+	Vector  string `json:"vector"`
+
+	// CVSS v2 exploitability metrics
+	AccessVector     string `json:"access_vector,omitempty"`
+	AccessComplexity string `json:"access_complexity,omitempty"`
+	Authentication   string `json:"authentication,omitempty"`
+
+	// CVSS v3/v4 exploitability metrics
+	AttackVector       string `json:"attack_vector,omitempty"`
+	AttackComplexity   string `json:"attack_complexity,omitempty"`
+	AttackRequirements string `json:"attack_requirements,omitempty"`
+	PrivilegesRequired string `json:"privileges_required,omitempty"`
+	UserInteraction    string `json:"user_interaction,omitempty"`
+	Scope              string `json:"scope,omitempty"`
+
+	// Impact metrics (the vulnerable system's C/I/A for v4)
+	ConfidentialityImpact string `json:"confidentiality_impact,omitempty"`
+	IntegrityImpact       string `json:"integrity_impact,omitempty"`
+	AvailabilityImpact    string `json:"availability_impact,omitempty"`
+
+	// CVSS v4 subsequent-system impact metrics
+	SubConfidentialityImpact string `json:"sub_confidentiality_impact,omitempty"`
+	SubIntegrityImpact       string `json:"sub_integrity_impact,omitempty"`
+	SubAvailabilityImpact    string `json:"sub_availability_impact,omitempty"`
+}
+
+// cvss2MetricValues maps each CVSS v2 base metric to its valid abbreviations
+// and expanded values, mirroring cvss3MetricValues
+var cvss2MetricValues = map[string]map[string]string{
+	"AV": {"L": "LOCAL", "A": "ADJACENT_NETWORK", "N": "NETWORK"},
+	"AC": {"H": "HIGH", "M": "MEDIUM", "L": "LOW"},
+	"Au": {"M": "MULTIPLE", "S": "SINGLE", "N": "NONE"},
+	"C":  {"N": "NONE", "P": "PARTIAL", "C": "COMPLETE"},
+	"I":  {"N": "NONE", "P": "PARTIAL", "C": "COMPLETE"},
+	"A":  {"N": "NONE", "P": "PARTIAL", "C": "COMPLETE"},
+}
+
+// cvss4MetricValues covers the eleven mandatory CVSS v4.0 base metrics
+var cvss4MetricValues = map[string]map[string]string{
+	"AV": {"N": "NETWORK", "A": "ADJACENT", "L": "LOCAL", "P": "PHYSICAL"},
+	"AC": {"L": "LOW", "H": "HIGH"},
+	"AT": {"N": "NONE", "P": "PRESENT"},
+	"PR": {"N": "NONE", "L": "LOW", "H": "HIGH"},
+	"UI": {"N": "NONE", "P": "PASSIVE", "A": "ACTIVE"},
+	"VC": {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"VI": {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"VA": {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"SC": {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"SI": {"H": "HIGH", "L": "LOW", "N": "NONE"},
+	"SA": {"H": "HIGH", "L": "LOW", "N": "NONE"},
+}
+
+// ParseCVSSVector parses a CVSS vector string of any supported version into
+// a CVSSVector. Vectors with a "CVSS:3.0", "CVSS:3.1" or "CVSS:4.0" prefix
+// are dispatched on the prefix; v2 vectors, which NVD ships without a prefix
+// (optionally parenthesized, e.g. "(AV:N/AC:L/Au:N/C:P/I:P/A:P)"), are
+// recognized by their metric set. Unknown versions, unknown metrics and
+// invalid values return descriptive errors.
+func ParseCVSSVector(vector string) (CVSSVector, error) {
+	switch {
+	case strings.HasPrefix(vector, "CVSS:3.0/"), strings.HasPrefix(vector, "CVSS:3.1/"):
+		cvss3, err := ParseCVSS3Vector(vector)
+		if err != nil {
+			return CVSSVector{}, err
+		}
+		return CVSSVector{
+			Version:               cvss3.Version,
+			Vector:                vector,
+			AttackVector:          cvss3.AttackVector,
+			AttackComplexity:      cvss3.AttackComplexity,
+			PrivilegesRequired:    cvss3.PrivilegesRequired,
+			UserInteraction:       cvss3.UserInteraction,
+			Scope:                 cvss3.Scope,
+			ConfidentialityImpact: cvss3.ConfidentialityImpact,
+			IntegrityImpact:       cvss3.IntegrityImpact,
+			AvailabilityImpact:    cvss3.AvailabilityImpact,
+		}, nil
+	case strings.HasPrefix(vector, "CVSS:4.0/"):
+		return parseCVSS4Vector(vector)
+	case strings.HasPrefix(vector, "CVSS:"):
+		return CVSSVector{}, fmt.Errorf("unsupported CVSS version in vector %q", vector)
+	default:
+		return parseCVSS2Vector(vector)
+	}
+}
+
+func parseCVSS2Vector(vector string) (CVSSVector, error) {
+	cvss := CVSSVector{Version: "2.0", Vector: vector}
+
+	// NVD wraps some v2 vectors in parentheses
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(vector, "("), ")")
+
+	assign := map[string]*string{
+		"AV": &cvss.AccessVector,
+		"AC": &cvss.AccessComplexity,
+		"Au": &cvss.Authentication,
+		"C":  &cvss.ConfidentialityImpact,
+		"I":  &cvss.IntegrityImpact,
+		"A":  &cvss.AvailabilityImpact,
+	}
+	if err := parseVectorMetrics(trimmed, vector, cvss2MetricValues, assign); err != nil {
+		return CVSSVector{}, err
+	}
+	return cvss, nil
+}
+
+func parseCVSS4Vector(vector string) (CVSSVector, error) {
+	cvss := CVSSVector{Version: "4.0", Vector: vector}
+
+	assign := map[string]*string{
+		"AV": &cvss.AttackVector,
+		"AC": &cvss.AttackComplexity,
+		"AT": &cvss.AttackRequirements,
+		"PR": &cvss.PrivilegesRequired,
+		"UI": &cvss.UserInteraction,
+		"VC": &cvss.ConfidentialityImpact,
+		"VI": &cvss.IntegrityImpact,
+		"VA": &cvss.AvailabilityImpact,
+		"SC": &cvss.SubConfidentialityImpact,
+		"SI": &cvss.SubIntegrityImpact,
+		"SA": &cvss.SubAvailabilityImpact,
+	}
+	if err := parseVectorMetrics(vector[len("CVSS:4.0/"):], vector, cvss4MetricValues, assign); err != nil {
+		return CVSSVector{}, err
+	}
+	return cvss, nil
+}
+
+// parseVectorMetrics walks the metric:value pairs of a vector, expanding each
+// abbreviation through the version's value table into the assigned field. All
+// metrics of the table are mandatory and may appear only once.
+func parseVectorMetrics(metrics string, vector string, table map[string]map[string]string, assign map[string]*string) error {
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(metrics, "/") {
+		metric, abbrev, found := strings.Cut(part, ":")
+		if !found {
+			return fmt.Errorf("malformed metric %q in vector %q", part, vector)
+		}
+		values, known := table[metric]
+		if !known {
+			return fmt.Errorf("unsupported metric %q in vector %q", metric, vector)
+		}
+		value, valid := values[abbrev]
+		if !valid {
+			return fmt.Errorf("invalid value %q for metric %q in vector %q", abbrev, metric, vector)
+		}
+		if seen[metric] {
+			return fmt.Errorf("duplicate metric %q in vector %q", metric, vector)
+		}
+		seen[metric] = true
+		*assign[metric] = value
+	}
+
+	for metric := range table {
+		if !seen[metric] {
+			return fmt.Errorf("missing base metric %q in vector %q", metric, vector)
+		}
+	}
+	return nil
+}
+
+// Score recomputes the base score from the parsed components so NVD data can
+// be validated against what the vector implies. v2 uses the CVSS v2 base
+// equation, v3.0/v3.1 reuse ComputeBaseScore. CVSS v4.0 base scores come from
+// the macro-vector lookup published by FIRST rather than a closed formula, so
+// recomputing them is not supported.
+func (v *CVSSVector) Score() (float64, error) {
+	switch v.Version {
+	case "2.0":
+		return v.computeV2BaseScore(), nil
+	case "3.0", "3.1":
+		cvss3 := CVSS3{
+			Version:               v.Version,
+			VectorString:          v.Vector,
+			AttackVector:          v.AttackVector,
+			AttackComplexity:      v.AttackComplexity,
+			PrivilegesRequired:    v.PrivilegesRequired,
+			UserInteraction:       v.UserInteraction,
+			Scope:                 v.Scope,
+			ConfidentialityImpact: v.ConfidentialityImpact,
+			IntegrityImpact:       v.IntegrityImpact,
+			AvailabilityImpact:    v.AvailabilityImpact,
+		}
+		return cvss3.ComputeBaseScore(), nil
+	case "4.0":
+		return 0, fmt.Errorf("recomputing CVSS v4.0 base scores is not supported")
+	default:
+		return 0, fmt.Errorf("unknown CVSS version %q", v.Version)
+	}
+}
+
+// computeV2BaseScore implements the CVSS v2 base equation
+func (v *CVSSVector) computeV2BaseScore() float64 {
+	impact := 10.41 * (1 -
+		(1-cvss2ImpactWeight(v.ConfidentialityImpact))*
+			(1-cvss2ImpactWeight(v.IntegrityImpact))*
+			(1-cvss2ImpactWeight(v.AvailabilityImpact)))
+
+	exploitability := 20 *
+		cvss2AccessVectorWeight(v.AccessVector) *
+		cvss2AccessComplexityWeight(v.AccessComplexity) *
+		cvss2AuthenticationWeight(v.Authentication)
+
+	fImpact := 1.176
+	if impact == 0 {
+		fImpact = 0
+	}
+
+	score := (0.6*impact + 0.4*exploitability - 1.5) * fImpact
+	return math.Round(score*10) / 10
+}
+
+func cvss2AccessVectorWeight(value string) float64 {
+	switch value {
+	case "LOCAL":
+		return 0.395
+	case "ADJACENT_NETWORK":
+		return 0.646
+	case "NETWORK":
+		return 1.0
+	}
+	return 0
+}
+
+func cvss2AccessComplexityWeight(value string) float64 {
+	switch value {
+	case "HIGH":
+		return 0.35
+	case "MEDIUM":
+		return 0.61
+	case "LOW":
+		return 0.71
+	}
+	return 0
+}
+
+func cvss2AuthenticationWeight(value string) float64 {
+	switch value {
+	case "MULTIPLE":
+		return 0.45
+	case "SINGLE":
+		return 0.56
+	case "NONE":
+		return 0.704
+	}
+	return 0
+}
+
+func cvss2ImpactWeight(value string) float64 {
+	switch value {
+	case "PARTIAL":
+		return 0.275
+	case "COMPLETE":
+		return 0.660
+	}
+	return 0
+}
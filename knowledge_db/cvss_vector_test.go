@@ -0,0 +1,73 @@
+package knowledge
+
+import "testing"
+
+func TestParseCVSSVectorV2(t *testing.T) {
+	parsed, err := ParseCVSSVector("(AV:N/AC:L/Au:N/C:P/I:P/A:P)")
+	if err != nil {
+		t.Fatalf("ParseCVSSVector returned error: %v", err)
+	}
+	if parsed.Version != "2.0" || parsed.AccessVector != "NETWORK" || parsed.Authentication != "NONE" || parsed.ConfidentialityImpact != "PARTIAL" {
+		t.Errorf("parsed = %+v", parsed)
+	}
+
+	// CVE-2002-0392 style vector scores 7.5 per the v2 specification
+	score, err := parsed.Score()
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	if score != 7.5 {
+		t.Errorf("Score = %v, want 7.5", score)
+	}
+}
+
+func TestParseCVSSVectorV3(t *testing.T) {
+	parsed, err := ParseCVSSVector("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")
+	if err != nil {
+		t.Fatalf("ParseCVSSVector returned error: %v", err)
+	}
+	if parsed.Version != "3.1" || parsed.AttackVector != "NETWORK" || parsed.Scope != "UNCHANGED" {
+		t.Errorf("parsed = %+v", parsed)
+	}
+
+	score, err := parsed.Score()
+	if err != nil {
+		t.Fatalf("Score returned error: %v", err)
+	}
+	if score != 9.8 {
+		t.Errorf("Score = %v, want 9.8", score)
+	}
+}
+
+func TestParseCVSSVectorV4(t *testing.T) {
+	parsed, err := ParseCVSSVector("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:N/SC:L/SI:N/SA:N")
+	if err != nil {
+		t.Fatalf("ParseCVSSVector returned error: %v", err)
+	}
+	if parsed.Version != "4.0" || parsed.AttackRequirements != "NONE" || parsed.UserInteraction != "NONE" {
+		t.Errorf("parsed = %+v", parsed)
+	}
+	if parsed.ConfidentialityImpact != "HIGH" || parsed.SubConfidentialityImpact != "LOW" {
+		t.Errorf("impact metrics = %+v", parsed)
+	}
+
+	// v4 base scores come from a lookup table, not a formula
+	if _, err := parsed.Score(); err == nil {
+		t.Errorf("expected error recomputing a v4 score")
+	}
+}
+
+func TestParseCVSSVectorErrors(t *testing.T) {
+	invalid := []string{
+		"CVSS:5.0/AV:N",                                    // unknown version
+		"AV:N/AC:L/Au:N/C:P/I:P",                           // missing v2 metric
+		"AV:N/AC:L/Au:X/C:P/I:P/A:P",                       // invalid v2 value
+		"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N",                // missing v4 metrics
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/A:H", // duplicate metric
+	}
+	for _, vector := range invalid {
+		if _, err := ParseCVSSVector(vector); err == nil {
+			t.Errorf("expected error for vector %q", vector)
+		}
+	}
+}
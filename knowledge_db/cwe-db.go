@@ -58,8 +58,10 @@ type TaxonomyMapping struct {
 }
 
 type PotentialMitigation struct {
-	Phases      []string `json:"Phase"`
-	Description string   `json:"Description"`
+	Phases        []string `json:"Phase"`
+	Description   string   `json:"Description"`
+	Strategy      string   `json:"Strategy"`
+	Effectiveness string   `json:"Effectiveness"`
 }
 
 type CommonConsequence struct {
@@ -96,6 +98,7 @@ type ApplicablePlatformEntry struct {
 }
 
 type DetectionMethod struct {
-	Method      string
-	Description string
+	Method        string
+	Description   string
+	Effectiveness string
 }
@@ -0,0 +1,36 @@
+package knowledge
+
+import "sort"
+
+// consequenceLikelihoodRank orders the CWE likelihood vocabulary so
+// consequences can be compared; unknown or empty likelihoods rank lowest
+var consequenceLikelihoodRank = map[string]int{
+	"High":   3,
+	"Medium": 2,
+	"Low":    1,
+}
+
+// MaxConsequenceLikelihood returns the highest likelihood found among the
+// entry's common consequences, or an empty string when none carries one
+func (c *CWEEntry) MaxConsequenceLikelihood() string {
+	best := ""
+	for _, consequence := range c.CommonConsequences {
+		if consequenceLikelihoodRank[consequence.Likelihood] > consequenceLikelihoodRank[best] {
+			best = consequence.Likelihood
+		}
+	}
+	return best
+}
+
+// RankedConsequences returns the entry's common consequences sorted by
+// likelihood, most likely first, so the UI can pick the headline consequence
+// of a weakness. The sort is stable: consequences with equal likelihood keep
+// their original order.
+func (c *CWEEntry) RankedConsequences() []CommonConsequence {
+	ranked := make([]CommonConsequence, len(c.CommonConsequences))
+	copy(ranked, c.CommonConsequences)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return consequenceLikelihoodRank[ranked[i].Likelihood] > consequenceLikelihoodRank[ranked[j].Likelihood]
+	})
+	return ranked
+}
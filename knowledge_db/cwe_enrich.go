@@ -0,0 +1,44 @@
+package knowledge
+
+// EnrichCWEs resolves a set of CWE ids to their full entries so vulnerability
+// views can display the weakness name and description instead of a bare id.
+// The lookup is injected so callers can back it with an in-memory map or a
+// database fetch. Unknown ids are skipped and duplicates resolved once.
+func EnrichCWEs(cweIDs []string, lookup func(id string) (CWEEntry, bool)) []CWEEntry {
+	seen := make(map[string]bool, len(cweIDs))
+	var entries []CWEEntry
+	for _, id := range cweIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if entry, exists := lookup(id); exists {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// CweIDs collects the CWE ids referenced by a GCVE item, combining the
+// pre-extracted Cwes column with the ids embedded in the problem types
+func (g *GCVEItem) CweIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range g.Cwes {
+		add(id)
+	}
+	for _, problemType := range g.ProblemTypes {
+		for _, description := range problemType.Descriptions {
+			add(description.CweId)
+		}
+	}
+	return ids
+}
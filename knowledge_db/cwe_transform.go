@@ -0,0 +1,82 @@
+package knowledge
+
+import "strings"
+
+// ExtractDetectionMethods flattens the nested detection-method descriptions
+// of a parsed CWE weakness into the plain-text DetectionMethod entries stored
+// in the database model. Without this transform the detection methods are
+// dropped entirely during the import.
+func ExtractDetectionMethods(weakness WeaknessCWE) []DetectionMethod {
+	var methods []DetectionMethod
+	for _, method := range weakness.DetectionMethods.DetectionMethod {
+		var parts []string
+		appendText(&parts, method.Description.Text)
+		appendText(&parts, method.Description.P...)
+		appendText(&parts, method.Description.Div.Text)
+		appendText(&parts, method.Description.Div.Div...)
+		for _, list := range method.Description.Div.Ul {
+			appendText(&parts, list.Li...)
+		}
+		for _, list := range method.Description.Ul {
+			appendText(&parts, list.Li...)
+		}
+
+		methods = append(methods, DetectionMethod{
+			Method:        method.Method,
+			Description:   strings.Join(parts, " "),
+			Effectiveness: method.Effectiveness,
+		})
+	}
+	return methods
+}
+
+// ExtractPotentialMitigations flattens the nested mitigation descriptions of
+// a parsed CWE weakness into the database model, keeping the recommended
+// strategy (e.g. "Input Validation") and its effectiveness
+func ExtractPotentialMitigations(weakness WeaknessCWE) []PotentialMitigation {
+	var mitigations []PotentialMitigation
+	for _, mitigation := range weakness.PotentialMitigations.Mitigation {
+		var parts []string
+		appendText(&parts, mitigation.Description.Text)
+		appendText(&parts, mitigation.Description.P...)
+		for _, list := range mitigation.Description.Ul {
+			appendText(&parts, list.Li...)
+		}
+		appendText(&parts, mitigation.Description.Div.Text)
+		appendText(&parts, mitigation.Description.Div.Div...)
+
+		mitigations = append(mitigations, PotentialMitigation{
+			Phases:        mitigation.Phase,
+			Description:   strings.Join(parts, " "),
+			Strategy:      mitigation.Strategy,
+			Effectiveness: mitigation.Effectiveness,
+		})
+	}
+	return mitigations
+}
+
+// PopulateMemberShips back-fills each weakness's MemberShips field with the
+// IDs of the categories that list it as a member. The field is not part of
+// the CWE XML schema, so without this pass it stays empty after parsing.
+func PopulateMemberShips(weaknesses []WeaknessCWE, categories []Category) {
+	memberOf := make(map[string][]string)
+	for _, category := range categories {
+		for _, member := range category.Relationships.HasMember {
+			memberOf[member.CWEID] = append(memberOf[member.CWEID], category.ID)
+		}
+	}
+
+	for i := range weaknesses {
+		weaknesses[i].MemberShips = memberOf[weaknesses[i].ID]
+	}
+}
+
+// appendText appends the non-empty trimmed values to parts
+func appendText(parts *[]string, values ...string) {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			*parts = append(*parts, value)
+		}
+	}
+}
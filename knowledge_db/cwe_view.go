@@ -0,0 +1,15 @@
+package knowledge
+
+// RelationshipsInView returns the related weaknesses scoped to a single CWE
+// view (e.g. "1000" for the Research view, "699" for Software Development).
+// CWE entries mix the relationships of every view into one list, so rendering
+// them unfiltered produces an incoherent weakness tree.
+func (c *CWEEntry) RelationshipsInView(viewID string) []RelatedWeakness {
+	var related []RelatedWeakness
+	for _, weakness := range c.RelatedWeaknesses {
+		if weakness.ViewID == viewID {
+			related = append(related, weakness)
+		}
+	}
+	return related
+}
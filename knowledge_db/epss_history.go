@@ -0,0 +1,148 @@
+package knowledge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// EPSSHistory stores a historical EPSS score for a CVE. A row is only
+// written when the score moved by more than a configured delta, so the
+// table tracks meaningful changes instead of daily noise.
+type EPSSHistory struct {
+	bun.BaseModel `bun:"table:epss_history,alias:eh"`
+	Id            uuid.UUID `bun:",pk,autoincrement,type:uuid,default:uuid_generate_v4()"`
+	CVE           string    `bun:"cve" json:"cve"`
+	Score         float32   `bun:"score" json:"score"`
+	Percentile    float32   `bun:"percentile" json:"percentile"`
+	RecordedOn    time.Time `bun:"recorded_on,default:current_timestamp" json:"recorded_on"`
+}
+
+// EPSSPoint is a single point of an EPSS trend
+type EPSSPoint struct {
+	Score      float32   `json:"score"`
+	Percentile float32   `json:"percentile"`
+	RecordedOn time.Time `json:"recorded_on"`
+}
+
+// EPSSSignificantChange describes an EPSS score jump above a threshold,
+// for the re-analysis orchestration and notification paths to consume
+type EPSSSignificantChange struct {
+	CVE      string  `json:"cve"`
+	OldScore float32 `json:"old_score"`
+	NewScore float32 `json:"new_score"`
+	Delta    float32 `json:"delta"`
+}
+
+// EPSSDeltaExceeds reports whether the score moved by more than minDelta,
+// gating whether a history row should be written for an import
+func EPSSDeltaExceeds(oldScore float32, newScore float32, minDelta float32) bool {
+	delta := newScore - oldScore
+	if delta < 0 {
+		delta = -delta
+	}
+	return delta > minDelta
+}
+
+// RecordEPSSHistory writes a history row for entry if its score differs from
+// the most recent recorded score by more than minDelta. It returns whether a
+// row was written. A CVE with no history yet is always recorded.
+func RecordEPSSHistory(ctx context.Context, db *bun.DB, entry EPSS, minDelta float32) (bool, error) {
+	var last EPSSHistory
+	err := db.NewSelect().
+		Model(&last).
+		Where("cve = ?", entry.CVE).
+		Order("recorded_on DESC").
+		Limit(1).
+		Scan(ctx)
+	switch {
+	case err == nil:
+		if !EPSSDeltaExceeds(last.Score, entry.Score, minDelta) {
+			return false, nil
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		// First observation of this CVE: always record
+	default:
+		return false, err
+	}
+
+	history := EPSSHistory{
+		CVE:        entry.CVE,
+		Score:      entry.Score,
+		Percentile: entry.Percentile,
+		RecordedOn: time.Now(),
+	}
+	if _, err := db.NewInsert().Model(&history).Exec(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetEPSSTrend returns the recorded EPSS points for a CVE within the given
+// time window, ordered from oldest to newest
+func GetEPSSTrend(ctx context.Context, db *bun.DB, cve string, window time.Duration) ([]EPSSPoint, error) {
+	var history []EPSSHistory
+	err := db.NewSelect().
+		Model(&history).
+		Where("cve = ?", cve).
+		Where("recorded_on >= ?", time.Now().Add(-window)).
+		Order("recorded_on ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]EPSSPoint, 0, len(history))
+	for _, entry := range history {
+		points = append(points, EPSSPoint{
+			Score:      entry.Score,
+			Percentile: entry.Percentile,
+			RecordedOn: entry.RecordedOn,
+		})
+	}
+	return points, nil
+}
+
+// DetectSignificantEPSSChanges compares two import snapshots and returns the
+// CVEs whose score moved by more than threshold. CVEs only present in the new
+// snapshot are ignored since there is no previous score to compare against.
+func DetectSignificantEPSSChanges(previous []EPSS, current []EPSS, threshold float32) []EPSSSignificantChange {
+	previousScores := make(map[string]float32, len(previous))
+	for _, entry := range previous {
+		previousScores[entry.CVE] = entry.Score
+	}
+
+	var changes []EPSSSignificantChange
+	for _, entry := range current {
+		oldScore, exists := previousScores[entry.CVE]
+		if !exists {
+			continue
+		}
+		if EPSSDeltaExceeds(oldScore, entry.Score, threshold) {
+			changes = append(changes, EPSSSignificantChange{
+				CVE:      entry.CVE,
+				OldScore: oldScore,
+				NewScore: entry.Score,
+				Delta:    entry.Score - oldScore,
+			})
+		}
+	}
+	return changes
+}
+
+// PruneEPSSHistory deletes history rows older than maxAge and returns the
+// number of rows removed
+func PruneEPSSHistory(ctx context.Context, db *bun.DB, maxAge time.Duration) (int64, error) {
+	res, err := db.NewDelete().
+		Model((*EPSSHistory)(nil)).
+		Where("recorded_on < ?", time.Now().Add(-maxAge)).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,39 @@
+package knowledge
+
+import "testing"
+
+func TestEPSSDeltaExceeds(t *testing.T) {
+	if EPSSDeltaExceeds(0.02, 0.03, 0.05) {
+		t.Error("Expected a 0.01 move to stay below a 0.05 delta")
+	}
+	if !EPSSDeltaExceeds(0.02, 0.6, 0.05) {
+		t.Error("Expected a 0.58 move to exceed a 0.05 delta")
+	}
+	// Drops count the same as jumps
+	if !EPSSDeltaExceeds(0.6, 0.02, 0.05) {
+		t.Error("Expected a drop to exceed the delta as well")
+	}
+}
+
+func TestDetectSignificantEPSSChanges(t *testing.T) {
+	previous := []EPSS{
+		{CVE: "CVE-2023-0001", Score: 0.02},
+		{CVE: "CVE-2023-0002", Score: 0.5},
+	}
+	current := []EPSS{
+		{CVE: "CVE-2023-0001", Score: 0.6},
+		{CVE: "CVE-2023-0002", Score: 0.51},
+		{CVE: "CVE-2023-0003", Score: 0.9}, // new CVE, no previous score
+	}
+
+	changes := DetectSignificantEPSSChanges(previous, current, 0.1)
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 significant change, got %d", len(changes))
+	}
+	if changes[0].CVE != "CVE-2023-0001" {
+		t.Errorf("Expected CVE-2023-0001, got %s", changes[0].CVE)
+	}
+	if changes[0].OldScore != 0.02 || changes[0].NewScore != 0.6 {
+		t.Errorf("Unexpected old/new scores: %f/%f", changes[0].OldScore, changes[0].NewScore)
+	}
+}
@@ -0,0 +1,44 @@
+package knowledge
+
+import "time"
+
+// publishedDateLayouts lists the timestamp formats used by the feeds'
+// published fields
+var publishedDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ExposureDays computes how many days a project has been exposed to the
+// vulnerability: the exposure window starts at the later of the publish date
+// and the moment the dependency was added, and runs until now. It returns 0
+// when the publish date cannot be parsed or the window has not started yet.
+func (v *UnifiedVuln) ExposureDays(dependencyAddedAt time.Time) int {
+	published, valid := parsePublishedDate(v.Published)
+	if !valid {
+		return 0
+	}
+
+	start := published
+	if dependencyAddedAt.After(start) {
+		start = dependencyAddedAt
+	}
+
+	days := int(time.Since(start).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// parsePublishedDate parses a feed publish date in any of the known layouts
+func parsePublishedDate(value string) (time.Time, bool) {
+	for _, layout := range publishedDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,95 @@
+package knowledge
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FixRecommendation describes the minimal version upgrade that resolves a
+// vulnerability for a package currently installed at a given version
+type FixRecommendation struct {
+	VulnerabilityId    string `json:"vulnerability_id"`
+	CurrentVersion     string `json:"current_version"`
+	RecommendedVersion string `json:"recommended_version"`
+	// BumpType is "major", "minor" or "patch" depending on which version
+	// component the upgrade changes
+	BumpType string `json:"bump_type"`
+}
+
+// ReconcileFixedVersions collects the fixed versions every source reports
+// for the vulnerability into one deduplicated, sorted list. Sources often
+// disagree on formatting (e.g. "1.2.3" vs "v1.2.3"); the version comparator
+// treats those as equal so each fix appears once. The fix-recommendation
+// feature consumes this single list instead of per-source ones.
+func ReconcileFixedVersions(vuln UnifiedVuln) []string {
+	var reconciled []string
+	for _, versions := range vuln.FixedVersions {
+		for _, version := range versions {
+			duplicate := false
+			for _, existing := range reconciled {
+				if CompareVersions(existing, version) == 0 {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				reconciled = append(reconciled, version)
+			}
+		}
+	}
+
+	// Sort ascending so the minimal fix comes first
+	sort.Slice(reconciled, func(i, j int) bool {
+		return CompareVersions(reconciled[i], reconciled[j]) < 0
+	})
+	return reconciled
+}
+
+// RecommendFix returns the minimal upgrade among availableVersions that
+// resolves the vulnerability: the nearest version at or above the minimal
+// fixed version that is above currentVersion. Comparing against any fixed
+// version would accept still-vulnerable candidates for multi-branch
+// advisories (fixes [1.2.5, 2.0.3], current 2.0.0: 2.0.1 is >= 1.2.5 but not
+// fixed). It returns an error when the advisory carries no fixed version
+// above the current one or no available version resolves it.
+func RecommendFix(vuln UnifiedVuln, currentVersion string, availableVersions []string) (*FixRecommendation, error) {
+	target := minimalFixAbove(vuln, currentVersion)
+	if target == "" {
+		return nil, fmt.Errorf("no fixed version known for %s", vuln.VulnerabilityId)
+	}
+
+	var best string
+	for _, candidate := range availableVersions {
+		if CompareVersions(candidate, target) < 0 {
+			continue
+		}
+		if best == "" || CompareVersions(candidate, best) < 0 {
+			best = candidate
+		}
+	}
+	if best == "" {
+		return nil, fmt.Errorf("no available version resolves %s", vuln.VulnerabilityId)
+	}
+
+	return &FixRecommendation{
+		VulnerabilityId:    vuln.VulnerabilityId,
+		CurrentVersion:     currentVersion,
+		RecommendedVersion: best,
+		BumpType:           bumpType(currentVersion, best),
+	}, nil
+}
+
+// bumpType classifies the upgrade from one version to another as a major,
+// minor or patch bump
+func bumpType(from string, to string) string {
+	fromMajor, fromMinor, _ := MajorMinorPatch(from)
+	toMajor, toMinor, _ := MajorMinorPatch(to)
+
+	if toMajor != fromMajor {
+		return "major"
+	}
+	if toMinor != fromMinor {
+		return "minor"
+	}
+	return "patch"
+}
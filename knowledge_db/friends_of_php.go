@@ -34,6 +34,20 @@ type FriendsOfPHPAffected struct {
 	AffectedVersions []string `json:"affected_versions"`
 }
 
+// BuildFriendsOfPHPIndex indexes the affected packages of all advisories by
+// Composer package name, so PHP vuln matching is an O(1) lookup instead of a
+// scan over every advisory. This gives the PHP path the same index-based
+// performance the OSV path gets from its prefetch.
+func BuildFriendsOfPHPIndex(advisories []FriendsOfPHPAdvisory) map[string][]FriendsOfPHPAffected {
+	index := make(map[string][]FriendsOfPHPAffected)
+	for _, advisory := range advisories {
+		for _, affected := range advisory.GetAffectedPackages() {
+			index[affected.PackageName] = append(index[affected.PackageName], affected)
+		}
+	}
+	return index
+}
+
 // GetAffectedPackages converts branch information to affected packages format
 func (f *FriendsOfPHPAdvisory) GetAffectedPackages() []FriendsOfPHPAffected {
 	var affected []FriendsOfPHPAffected
@@ -0,0 +1,153 @@
+package knowledge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsVersionAffected evaluates the advisory's branch constraints against a
+// concrete version using Composer semantics. Within one branch the
+// comma-joined constraints are AND-ed; across branches any matching branch
+// marks the version affected. Stability suffixes like -RC1 and -dev are
+// handled by the shared version comparator.
+func (f *FriendsOfPHPAdvisory) IsVersionAffected(version string) (bool, error) {
+	if version == "" {
+		return false, fmt.Errorf("empty version")
+	}
+	if len(f.Branches) == 0 {
+		return false, fmt.Errorf("advisory %s has no branches", f.AdvisoryId)
+	}
+
+	for _, branch := range f.Branches {
+		matches, err := branchMatches(branch, version)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// branchMatches reports whether the version satisfies every constraint of a
+// branch. Entries in the versions list may themselves be comma-joined, so
+// each entry is split before evaluation.
+func branchMatches(branch AdvisoryBranch, version string) (bool, error) {
+	evaluated := false
+	for _, entry := range branch.Versions {
+		for _, constraint := range strings.Split(entry, ",") {
+			constraint = strings.TrimSpace(constraint)
+			if constraint == "" {
+				continue
+			}
+			evaluated = true
+			matches, err := matchesComposerConstraint(constraint, version)
+			if err != nil {
+				return false, err
+			}
+			if !matches {
+				return false, nil
+			}
+		}
+	}
+	return evaluated, nil
+}
+
+// matchesComposerConstraint evaluates a single Composer constraint like
+// ">=2.0", "<2.1.3", "~2.1" or "^1.2.3" against a version
+func matchesComposerConstraint(constraint string, version string) (bool, error) {
+	switch {
+	case strings.HasPrefix(constraint, ">="):
+		return CompareVersions(version, constraint[2:]) >= 0, nil
+	case strings.HasPrefix(constraint, "<="):
+		return CompareVersions(version, constraint[2:]) <= 0, nil
+	case strings.HasPrefix(constraint, ">"):
+		return CompareVersions(version, constraint[1:]) > 0, nil
+	case strings.HasPrefix(constraint, "<"):
+		return CompareVersions(version, constraint[1:]) < 0, nil
+	case strings.HasPrefix(constraint, "!="):
+		return CompareVersions(version, constraint[2:]) != 0, nil
+	case strings.HasPrefix(constraint, "=="):
+		return CompareVersions(version, constraint[2:]) == 0, nil
+	case strings.HasPrefix(constraint, "="):
+		return CompareVersions(version, constraint[1:]) == 0, nil
+	case strings.HasPrefix(constraint, "~"):
+		lower := strings.TrimSpace(constraint[1:])
+		upper, err := tildeUpperBound(lower)
+		if err != nil {
+			return false, err
+		}
+		return CompareVersions(version, lower) >= 0 && CompareVersions(version, upper) < 0, nil
+	case strings.HasPrefix(constraint, "^"):
+		lower := strings.TrimSpace(constraint[1:])
+		upper, err := caretUpperBound(lower)
+		if err != nil {
+			return false, err
+		}
+		return CompareVersions(version, lower) >= 0 && CompareVersions(version, upper) < 0, nil
+	case constraint == "*":
+		return true, nil
+	default:
+		// A bare version is an exact match
+		return CompareVersions(version, constraint) == 0, nil
+	}
+}
+
+// tildeUpperBound computes the exclusive upper bound of a Composer ~
+// constraint: the last given segment may change, so ~2.1.3 allows <2.2 and
+// ~2.1 allows <3.0
+func tildeUpperBound(lower string) (string, error) {
+	segments, err := releaseSegments(lower)
+	if err != nil {
+		return "", err
+	}
+	if len(segments) < 2 {
+		return "", fmt.Errorf("tilde constraint needs at least two segments: ~%s", lower)
+	}
+	segments = segments[:len(segments)-1]
+	segments[len(segments)-1]++
+	return joinSegments(segments), nil
+}
+
+// caretUpperBound computes the exclusive upper bound of a Composer ^
+// constraint: no breaking change, so ^2.1.3 allows <3.0 while ^0.3.2 stays
+// below 0.4
+func caretUpperBound(lower string) (string, error) {
+	segments, err := releaseSegments(lower)
+	if err != nil {
+		return "", err
+	}
+	if segments[0] == 0 && len(segments) > 1 {
+		return joinSegments([]int{0, segments[1] + 1}), nil
+	}
+	return joinSegments([]int{segments[0] + 1, 0}), nil
+}
+
+// releaseSegments parses the numeric dot-separated release segments of a
+// version, ignoring any stability suffix
+func releaseSegments(version string) ([]int, error) {
+	release, _ := splitVersion(version)
+	parts := strings.Split(release, ".")
+	segments := make([]int, 0, len(parts))
+	for _, part := range parts {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("non-numeric segment %q in version %q", part, version)
+		}
+		segments = append(segments, num)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("empty version")
+	}
+	return segments, nil
+}
+
+func joinSegments(segments []int) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = strconv.Itoa(segment)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,80 @@
+package knowledge
+
+import "testing"
+
+func TestFriendsOfPHPIsVersionAffected(t *testing.T) {
+	advisory := FriendsOfPHPAdvisory{
+		AdvisoryId: "vendor/package/2024-01-01",
+		Branches: map[string]AdvisoryBranch{
+			"2.0.x": {Versions: []string{">=2.0,<2.1.3"}},
+			"2.2.x": {Versions: []string{">=2.2", "<2.2.5"}},
+		},
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"1.9.0", false},
+		{"2.0.0", true},
+		{"2.1.2", true},
+		{"2.1.3", false}, // upper bound is exclusive
+		{"2.1.5", false}, // between the two branches
+		{"2.2.0", true},  // second branch matches
+		{"2.2.5", false},
+	}
+	for _, c := range cases {
+		got, err := advisory.IsVersionAffected(c.version)
+		if err != nil {
+			t.Fatalf("IsVersionAffected(%q) returned error: %v", c.version, err)
+		}
+		if got != c.want {
+			t.Errorf("IsVersionAffected(%q) = %v, want %v", c.version, got, c.want)
+		}
+	}
+}
+
+func TestFriendsOfPHPIsVersionAffectedStabilitySuffixes(t *testing.T) {
+	advisory := FriendsOfPHPAdvisory{
+		AdvisoryId: "vendor/package/2024-02-02",
+		Branches: map[string]AdvisoryBranch{
+			"2.1.x": {Versions: []string{">=2.0,<2.1.0"}},
+		},
+	}
+
+	// 2.1.0-RC1 sorts below the 2.1.0 release, so it is still affected
+	if got, _ := advisory.IsVersionAffected("2.1.0-RC1"); !got {
+		t.Errorf("2.1.0-RC1 should be affected by <2.1.0")
+	}
+	if got, _ := advisory.IsVersionAffected("2.1.0"); got {
+		t.Errorf("2.1.0 should not be affected by <2.1.0")
+	}
+}
+
+func TestFriendsOfPHPIsVersionAffectedTildeCaret(t *testing.T) {
+	tilde := FriendsOfPHPAdvisory{
+		AdvisoryId: "vendor/package/2024-03-03",
+		Branches: map[string]AdvisoryBranch{
+			"2.1.x": {Versions: []string{"~2.1.0"}},
+		},
+	}
+	if got, _ := tilde.IsVersionAffected("2.1.7"); !got {
+		t.Errorf("~2.1.0 should allow 2.1.7")
+	}
+	if got, _ := tilde.IsVersionAffected("2.2.0"); got {
+		t.Errorf("~2.1.0 should not allow 2.2.0")
+	}
+
+	caret := FriendsOfPHPAdvisory{
+		AdvisoryId: "vendor/package/2024-04-04",
+		Branches: map[string]AdvisoryBranch{
+			"1.x": {Versions: []string{"^1.2.3"}},
+		},
+	}
+	if got, _ := caret.IsVersionAffected("1.9.0"); !got {
+		t.Errorf("^1.2.3 should allow 1.9.0")
+	}
+	if got, _ := caret.IsVersionAffected("2.0.0"); got {
+		t.Errorf("^1.2.3 should not allow 2.0.0")
+	}
+}
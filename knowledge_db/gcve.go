@@ -29,6 +29,37 @@ type GCVEItem struct {
 	VlaiConfidence    float64           `bun:"vlai_confidence"`
 }
 
+// BuildAffectedFlattened populates AffectedFlattened with the unique
+// vendor+product pairs from both the CNA Affected list and the ADP
+// enrichments, so the JSONB containment queries the column exists for
+// actually have data to match against
+func (g *GCVEItem) BuildAffectedFlattened() {
+	seen := make(map[GCVEProduct]bool)
+	products := make([]GCVEProduct, 0, len(g.Affected))
+
+	add := func(affected []GCVEAffected) {
+		for _, entry := range affected {
+			product := GCVEProduct{
+				Vendor:  entry.Vendor,
+				Product: entry.Product,
+			}
+			if product.Vendor == "" && product.Product == "" {
+				continue
+			}
+			if !seen[product] {
+				seen[product] = true
+				products = append(products, product)
+			}
+		}
+	}
+
+	add(g.Affected)
+	for _, adp := range g.ADPEnrichments {
+		add(adp.Affected)
+	}
+	g.AffectedFlattened = products
+}
+
 type GCVEDescription struct {
 	Lang  string `json:"lang"`
 	Value string `json:"value"`
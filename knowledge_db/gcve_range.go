@@ -0,0 +1,67 @@
+package knowledge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsVersionAffected reports whether the given concrete version is affected
+// according to the CVE Record v5 version range semantics: an entry with
+// `lessThan` covers `[version, lessThan)`, `lessThanOrEqual` is inclusive,
+// and a bare entry matches only its exact version. Versions not covered by
+// any entry take the affected entry's defaultStatus. Semver-typed entries
+// are compared with the shared version comparator; unknown versionTypes fall
+// back to plain string comparison.
+func (a *GCVEAffected) IsVersionAffected(version string) (bool, error) {
+	if version == "" {
+		return false, fmt.Errorf("empty version")
+	}
+
+	for _, entry := range a.Versions {
+		covered, err := versionEntryCovers(entry, version)
+		if err != nil {
+			return false, err
+		}
+		if covered {
+			return entry.Status == "affected", nil
+		}
+	}
+	return a.DefaultStatus == "affected", nil
+}
+
+// versionEntryCovers reports whether a GCVEVersion entry's range contains the
+// given version
+func versionEntryCovers(entry GCVEVersion, version string) (bool, error) {
+	compare := compareForVersionType(entry.VersionType)
+
+	// A wildcard start ("0" or "*") covers everything below the upper bound
+	atOrAboveStart := entry.Version == "0" || entry.Version == "*" ||
+		compare(version, entry.Version) >= 0
+
+	switch {
+	case entry.LessThan != "":
+		return atOrAboveStart && compare(version, entry.LessThan) < 0, nil
+	case entry.LessThanOrEqual != "":
+		if entry.LessThanOrEqual == "*" {
+			return atOrAboveStart, nil
+		}
+		return atOrAboveStart && compare(version, entry.LessThanOrEqual) <= 0, nil
+	case entry.Version != "":
+		return compare(version, entry.Version) == 0, nil
+	default:
+		return false, fmt.Errorf("version entry without version or range bounds")
+	}
+}
+
+// compareForVersionType picks the comparison for a CVE v5 versionType.
+// Semver-like types use the shared version comparator; custom and unknown
+// types fall back to plain string comparison since their ordering is
+// vendor-defined.
+func compareForVersionType(versionType string) func(a, b string) int {
+	switch strings.ToLower(versionType) {
+	case "", "semver", "maven", "python", "rpm":
+		return CompareVersions
+	default:
+		return strings.Compare
+	}
+}
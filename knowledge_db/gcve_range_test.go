@@ -0,0 +1,80 @@
+package knowledge
+
+import "testing"
+
+func TestGCVEIsVersionAffectedMixedEntries(t *testing.T) {
+	affected := GCVEAffected{
+		DefaultStatus: "unaffected",
+		Versions: []GCVEVersion{
+			{Version: "1.0.0", Status: "affected", LessThan: "1.4.0", VersionType: "semver"},
+			{Version: "2.0.0", Status: "affected", LessThanOrEqual: "2.2.0", VersionType: "semver"},
+			{Version: "1.2.5", Status: "unaffected", VersionType: "semver"},
+		},
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"0.9.0", false}, // below every range, takes defaultStatus
+		{"1.0.0", true},  // range start is inclusive
+		{"1.2.5", true},  // first matching entry wins; covered by the first range
+		{"1.4.0", false}, // lessThan is exclusive
+		{"2.2.0", true},  // lessThanOrEqual is inclusive
+		{"2.3.0", false}, // above the second range
+	}
+	for _, c := range cases {
+		got, err := affected.IsVersionAffected(c.version)
+		if err != nil {
+			t.Fatalf("IsVersionAffected(%q) returned error: %v", c.version, err)
+		}
+		if got != c.want {
+			t.Errorf("IsVersionAffected(%q) = %v, want %v", c.version, got, c.want)
+		}
+	}
+}
+
+func TestGCVEIsVersionAffectedDefaultAffected(t *testing.T) {
+	affected := GCVEAffected{
+		DefaultStatus: "affected",
+		Versions: []GCVEVersion{
+			{Version: "3.1.7", Status: "unaffected", VersionType: "semver"},
+			{Version: "4.0.0", Status: "unaffected", LessThan: "5.0.0", VersionType: "semver"},
+		},
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.0", true},  // not listed, defaultStatus applies
+		{"3.1.7", false}, // explicit unaffected exception
+		{"4.5.0", false}, // inside the unaffected range
+		{"5.0.0", true},  // past the unaffected range, back to default
+	}
+	for _, c := range cases {
+		got, err := affected.IsVersionAffected(c.version)
+		if err != nil {
+			t.Fatalf("IsVersionAffected(%q) returned error: %v", c.version, err)
+		}
+		if got != c.want {
+			t.Errorf("IsVersionAffected(%q) = %v, want %v", c.version, got, c.want)
+		}
+	}
+}
+
+func TestGCVEIsVersionAffectedCustomType(t *testing.T) {
+	// Unknown version types only support exact string matches
+	affected := GCVEAffected{
+		DefaultStatus: "unaffected",
+		Versions: []GCVEVersion{
+			{Version: "build-2024a", Status: "affected", VersionType: "vendor"},
+		},
+	}
+	if got, _ := affected.IsVersionAffected("build-2024a"); !got {
+		t.Errorf("exact match on custom version type should be affected")
+	}
+	if got, _ := affected.IsVersionAffected("build-2024b"); got {
+		t.Errorf("non-matching custom version should take defaultStatus")
+	}
+}
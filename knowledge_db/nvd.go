@@ -1,7 +1,7 @@
 package knowledge
 
 import (
-	"strings"
+	"log"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -128,10 +128,58 @@ type ImpactMetricV30 struct {
 	Type                string  `json:"type"`
 }
 
+// CVSS40 mirrors the CVSS v4.0 cvssData shape of the NVD 2.0 API, covering
+// the base metrics plus the threat, environmental and supplemental groups
+type CVSS40 struct {
+	Version                           string  `json:"version"`
+	VectorString                      string  `json:"vectorString"`
+	BaseScore                         float64 `json:"baseScore"`
+	BaseSeverity                      string  `json:"baseSeverity"`
+	AttackVector                      string  `json:"attackVector,omitempty"`
+	AttackComplexity                  string  `json:"attackComplexity,omitempty"`
+	AttackRequirements                string  `json:"attackRequirements,omitempty"`
+	PrivilegesRequired                string  `json:"privilegesRequired,omitempty"`
+	UserInteraction                   string  `json:"userInteraction,omitempty"`
+	VulnConfidentialityImpact         string  `json:"vulnConfidentialityImpact,omitempty"`
+	VulnIntegrityImpact               string  `json:"vulnIntegrityImpact,omitempty"`
+	VulnAvailabilityImpact            string  `json:"vulnAvailabilityImpact,omitempty"`
+	SubConfidentialityImpact          string  `json:"subConfidentialityImpact,omitempty"`
+	SubIntegrityImpact                string  `json:"subIntegrityImpact,omitempty"`
+	SubAvailabilityImpact             string  `json:"subAvailabilityImpact,omitempty"`
+	ExploitMaturity                   string  `json:"exploitMaturity,omitempty"`
+	ConfidentialityRequirement        string  `json:"confidentialityRequirement,omitempty"`
+	IntegrityRequirement              string  `json:"integrityRequirement,omitempty"`
+	AvailabilityRequirement           string  `json:"availabilityRequirement,omitempty"`
+	ModifiedAttackVector              string  `json:"modifiedAttackVector,omitempty"`
+	ModifiedAttackComplexity          string  `json:"modifiedAttackComplexity,omitempty"`
+	ModifiedAttackRequirements        string  `json:"modifiedAttackRequirements,omitempty"`
+	ModifiedPrivilegesRequired        string  `json:"modifiedPrivilegesRequired,omitempty"`
+	ModifiedUserInteraction           string  `json:"modifiedUserInteraction,omitempty"`
+	ModifiedVulnConfidentialityImpact string  `json:"modifiedVulnConfidentialityImpact,omitempty"`
+	ModifiedVulnIntegrityImpact       string  `json:"modifiedVulnIntegrityImpact,omitempty"`
+	ModifiedVulnAvailabilityImpact    string  `json:"modifiedVulnAvailabilityImpact,omitempty"`
+	ModifiedSubConfidentialityImpact  string  `json:"modifiedSubConfidentialityImpact,omitempty"`
+	ModifiedSubIntegrityImpact        string  `json:"modifiedSubIntegrityImpact,omitempty"`
+	ModifiedSubAvailabilityImpact     string  `json:"modifiedSubAvailabilityImpact,omitempty"`
+	Safety                            string  `json:"Safety,omitempty"`
+	Automatable                       string  `json:"Automatable,omitempty"`
+	Recovery                          string  `json:"Recovery,omitempty"`
+	ValueDensity                      string  `json:"valueDensity,omitempty"`
+	VulnerabilityResponseEffort       string  `json:"vulnerabilityResponseEffort,omitempty"`
+	ProviderUrgency                   string  `json:"providerUrgency,omitempty"`
+}
+
+type ImpactMetricV40 struct {
+	CvssData CVSS40 `json:"cvssData"`
+	Source   string `json:"source"`
+	Type     string `json:"type"`
+}
+
 type Metrics struct {
 	CvssMetricV2  []ImpactMetricV2  `json:"cvssMetricV2"`
 	CvssMetricV30 []ImpactMetricV30 `json:"cvssMetricV30"`
 	CvssMetricV31 []ImpactMetricV31 `json:"cvssMetricV31"`
+	CvssMetricV40 []ImpactMetricV40 `json:"cvssMetricV40"`
 }
 type Weaknesses struct {
 	Source       string         `json:"source"`
@@ -218,13 +266,9 @@ func createAffected(cve NVDItem) []NVDAffected {
 			//   running-on-applicaitons-only: django
 
 			if config.Operator == "AND" {
-				if len(config.Children) < 2 {
+				if len(config.Children) == 0 {
 					if len(config.CpeMatch) > 0 {
-						sources := config.CpeMatch
-
-						for source := range sources {
-							sources[source].CriteriaDict = parseConfig(sources[source])
-						}
+						sources := parseSources(cve.NVDId, config.CpeMatch)
 
 						if validateLibrary(sources) {
 							affected = append(affected, NVDAffected{
@@ -235,15 +279,18 @@ func createAffected(cve NVDItem) []NVDAffected {
 						}
 					}
 				} else {
-					running_on := config.Children[1].CpeMatch
-					sources := config.Children[0].CpeMatch
-
-					for run_on := range running_on {
-						running_on[run_on].CriteriaDict = parseConfig(running_on[run_on])
-					}
-
-					for source := range sources {
-						sources[source].CriteriaDict = parseConfig(sources[source])
+					// NVD sometimes emits more than two children; the
+					// application child is the source regardless of its
+					// position and the others describe what it runs on
+					sources := []Sources{}
+					running_on := []Sources{}
+					for _, child := range config.Children {
+						parsed := parseSources(cve.NVDId, child.CpeMatch)
+						if validateLibrary(parsed) {
+							sources = append(sources, parsed...)
+						} else {
+							running_on = append(running_on, parsed...)
+						}
 					}
 
 					// We only insert the affected object into the report if the report is about a library / application that is vulnerable
@@ -257,10 +304,7 @@ func createAffected(cve NVDItem) []NVDAffected {
 					}
 				}
 			} else if config.Operator == "OR" {
-				sources := config.CpeMatch
-				for source := range sources {
-					sources[source].CriteriaDict = parseConfig(sources[source])
-				}
+				sources := parseSources(cve.NVDId, config.CpeMatch)
 
 				// We only insert the affected object into the report if the report is about a library / application that is vulnerable
 				// We dont care about vulnerabilities about hardware systems or operating systems
@@ -278,25 +322,25 @@ func createAffected(cve NVDItem) []NVDAffected {
 	return affected
 }
 
-func parseConfig(config Sources) CriteriaDict {
-	// parsed_cpe = cpe_parser(config["cpe23Uri"])
-	// config["cpe23Wfn"] = parsed_cpe.as_wfn()
-	criteria_string := strings.Split(config.Criteria, ":")
-	criteria := CriteriaDict{
-		Part:      criteria_string[2],
-		Vendor:    criteria_string[3],
-		Product:   criteria_string[4],
-		Version:   criteria_string[5],
-		Update:    criteria_string[6],
-		Edition:   criteria_string[7],
-		Language:  criteria_string[8],
-		SwEdition: criteria_string[9],
-		TargetSw:  criteria_string[10],
-		TargetHw:  criteria_string[11],
-		Other:     criteria_string[12],
-	}
+func parseConfig(config Sources) (CriteriaDict, error) {
+	return ParseCPE23(config.Criteria)
+}
 
-	return criteria
+// parseSources parses the CPE criteria of each source, dropping the ones
+// that fail to parse. Some real NVD records carry malformed criteria; they
+// are logged with the CVE id and skipped instead of aborting the import.
+func parseSources(cveId string, sources []Sources) []Sources {
+	parsed := make([]Sources, 0, len(sources))
+	for _, source := range sources {
+		criteria, err := parseConfig(source)
+		if err != nil {
+			log.Printf("%s: skipping invalid CPE criteria %q: %v", cveId, source.Criteria, err)
+			continue
+		}
+		source.CriteriaDict = criteria
+		parsed = append(parsed, source)
+	}
+	return parsed
 }
 
 // checks if the vulnerability is for a library or not
@@ -0,0 +1,77 @@
+package knowledge
+
+// PrimaryV31 returns the CVSS v3.1 metric entry published by the primary
+// source (the NVD analysis), falling back to the first entry when no primary
+// is marked. NVD records can carry additional secondary entries from CNAs
+// whose scores differ from the authoritative one.
+func (m *Metrics) PrimaryV31() (*ImpactMetricV31, bool) {
+	if len(m.CvssMetricV31) == 0 {
+		return nil, false
+	}
+	for i := range m.CvssMetricV31 {
+		if m.CvssMetricV31[i].Type == "Primary" {
+			return &m.CvssMetricV31[i], true
+		}
+	}
+	return &m.CvssMetricV31[0], true
+}
+
+// BestCVSS returns the score, severity, vector string and CVSS version of
+// the highest CVSS version available on the metrics, preferring v4.0 over
+// v3.1 over v3.0 over v2, so downstream code picks the richest data without
+// checking each slice itself
+func (m *Metrics) BestCVSS() (float64, string, string, string, bool) {
+	if len(m.CvssMetricV40) > 0 {
+		data := m.CvssMetricV40[0].CvssData
+		return data.BaseScore, data.BaseSeverity, data.VectorString, "4.0", true
+	}
+	if metric, exists := m.PrimaryV31(); exists {
+		data := metric.CvssData
+		return data.BaseScore, data.BaseSeverity, data.VectorString, "3.1", true
+	}
+	if len(m.CvssMetricV30) > 0 {
+		data := m.CvssMetricV30[0].CvssData
+		return data.BaseScore, data.BaseSeverity, data.VectorString, "3.0", true
+	}
+	if len(m.CvssMetricV2) > 0 {
+		metric := m.CvssMetricV2[0]
+		return metric.CvssData.BaseScore, metric.BaseSeverity, metric.CvssData.VectorString, "2.0", true
+	}
+	return 0, "", "", "", false
+}
+
+// Subscores carries the exploitability and impact subscores of a CVSS metric
+// together with the CVSS version they were taken from
+type Subscores struct {
+	ExploitabilityScore float64 `json:"exploitability_score"`
+	ImpactScore         float64 `json:"impact_score"`
+	Version             string  `json:"version"`
+}
+
+// Subscores returns the exploitability and impact subscores for whichever
+// metric version is present, preferring v3.1 over v3.0 over v2, so dashboards
+// can show the breakdown without caring how each metric type stores it
+func (m *Metrics) Subscores() (Subscores, bool) {
+	if metric, exists := m.PrimaryV31(); exists {
+		return Subscores{
+			ExploitabilityScore: metric.ExploitabilityScore,
+			ImpactScore:         metric.ImpactScore,
+			Version:             "3.1",
+		}, true
+	}
+	if len(m.CvssMetricV30) > 0 {
+		return Subscores{
+			ExploitabilityScore: m.CvssMetricV30[0].ExploitabilityScore,
+			ImpactScore:         m.CvssMetricV30[0].ImpactScore,
+			Version:             "3.0",
+		}, true
+	}
+	if len(m.CvssMetricV2) > 0 {
+		return Subscores{
+			ExploitabilityScore: m.CvssMetricV2[0].ExploitabilityScore,
+			ImpactScore:         m.CvssMetricV2[0].ImpactScore,
+			Version:             "2.0",
+		}, true
+	}
+	return Subscores{}, false
+}
@@ -0,0 +1,30 @@
+package knowledge
+
+// The vulnStatus values the NVD API assigns to a CVE record
+const (
+	VulnStatusAnalyzed         = "Analyzed"
+	VulnStatusAwaitingAnalysis = "Awaiting Analysis"
+	VulnStatusUndergoing       = "Undergoing Analysis"
+	VulnStatusModified         = "Modified"
+	VulnStatusRejected         = "Rejected"
+	VulnStatusDeferred         = "Deferred"
+	VulnStatusReceived         = "Received"
+)
+
+// IsAnalyzed reports whether the NVD analysis of the record is complete,
+// meaning CVSS metrics and CPE configurations can be expected to be present
+func (n *NVDItem) IsAnalyzed() bool {
+	return n.VulnStatus == VulnStatusAnalyzed || n.VulnStatus == VulnStatusModified
+}
+
+// ShouldInclude reports whether the record is worth importing: rejected
+// CVEs are withdrawn and records still awaiting analysis carry no CVSS data
+// and confuse users when surfaced as findings
+func (n *NVDItem) ShouldInclude() bool {
+	switch n.VulnStatus {
+	case VulnStatusRejected, VulnStatusAwaitingAnalysis, VulnStatusReceived:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,105 @@
+package knowledge
+
+import "testing"
+
+func TestParseCPE23(t *testing.T) {
+	criteria, err := ParseCPE23(`cpe:2.3:a:vendor:product:1.0.0:*:*:*:*:node.js:*:*`)
+	if err != nil {
+		t.Fatalf("ParseCPE23 returned error: %v", err)
+	}
+	if criteria.Part != "a" || criteria.Vendor != "vendor" || criteria.Product != "product" {
+		t.Errorf("unexpected attributes: %+v", criteria)
+	}
+	if criteria.Version != "1.0.0" || criteria.TargetSw != "node.js" {
+		t.Errorf("unexpected version/target_sw: %+v", criteria)
+	}
+
+	// An escaped colon must not split the field
+	escaped, err := ParseCPE23(`cpe:2.3:a:vendor:product:1.0\:beta:*:*:*:*:*:*:*`)
+	if err != nil {
+		t.Fatalf("ParseCPE23 with escaped colon returned error: %v", err)
+	}
+	if escaped.Version != `1.0\:beta` {
+		t.Errorf("escaped colon split the version field: %q", escaped.Version)
+	}
+
+	if _, err := ParseCPE23("cpe:2.3:a:vendor:product"); err == nil {
+		t.Errorf("expected error for truncated CPE")
+	}
+	if _, err := ParseCPE23("cpe:/a:vendor:product:1.0"); err == nil {
+		t.Errorf("expected error for CPE 2.2 URI")
+	}
+}
+
+func TestCreateAffectedThreeChildAndNode(t *testing.T) {
+	nvd := NVD{
+		Vulnerabilities: []map[string]NVDItem{{
+			"cve": {
+				NVDId: "CVE-2024-0002",
+				Configurations: []Configuration{{
+					Nodes: []Node{{
+						Operator: "AND",
+						Children: []Node{
+							// The application child is not in position 0
+							{Operator: "OR", CpeMatch: []Sources{
+								{Vulnerable: false, Criteria: "cpe:2.3:o:microsoft:windows:-:*:*:*:*:*:*:*"},
+							}},
+							{Operator: "OR", CpeMatch: []Sources{
+								{Vulnerable: true, Criteria: "cpe:2.3:a:vendor:library:1.0.0:*:*:*:*:*:*:*"},
+							}},
+							{Operator: "OR", CpeMatch: []Sources{
+								{Vulnerable: false, Criteria: "cpe:2.3:h:vendor:device:-:*:*:*:*:*:*:*"},
+							}},
+						},
+					}},
+				}},
+			},
+		}},
+	}
+
+	vulns := GetVulns(nvd)
+	if len(vulns) != 1 || len(vulns[0].Affected) != 1 {
+		t.Fatalf("expected 1 vulnerability with 1 affected entry, got %+v", vulns)
+	}
+
+	entry := vulns[0].Affected[0]
+	if len(entry.Sources) != 1 || entry.Sources[0].CriteriaDict.Product != "library" {
+		t.Errorf("application child not grouped as source: %+v", entry.Sources)
+	}
+	if len(entry.RunningOn) != 2 {
+		t.Errorf("expected 2 running-on entries, got %d", len(entry.RunningOn))
+	}
+	if len(entry.RunningOnApplicationsOnly) != 0 {
+		t.Errorf("expected no application running-on entries, got %d", len(entry.RunningOnApplicationsOnly))
+	}
+}
+
+func TestGetVulnsSkipsMalformedCriteria(t *testing.T) {
+	nvd := NVD{
+		Vulnerabilities: []map[string]NVDItem{{
+			"cve": {
+				NVDId: "CVE-2024-0001",
+				Configurations: []Configuration{{
+					Nodes: []Node{{
+						Operator: "OR",
+						CpeMatch: []Sources{
+							{Vulnerable: true, Criteria: "cpe:2.3:a:vendor:product"}, // truncated
+							{Vulnerable: true, Criteria: "cpe:2.3:a:vendor:product:1.0.0:*:*:*:*:*:*:*"},
+						},
+					}},
+				}},
+			},
+		}},
+	}
+
+	vulns := GetVulns(nvd)
+	if len(vulns) != 1 {
+		t.Fatalf("expected 1 vulnerability, got %d", len(vulns))
+	}
+	if len(vulns[0].AffectedFlattened) != 1 {
+		t.Fatalf("expected the malformed source to be skipped, got %d sources", len(vulns[0].AffectedFlattened))
+	}
+	if vulns[0].AffectedFlattened[0].CriteriaDict.Product != "product" {
+		t.Errorf("valid source not parsed: %+v", vulns[0].AffectedFlattened[0].CriteriaDict)
+	}
+}
@@ -0,0 +1,60 @@
+package knowledge
+
+import "fmt"
+
+// IsVersionAffected reports whether the given concrete version falls inside
+// the affected entry's ranges or explicit version list. SEMVER and ECOSYSTEM
+// ranges are evaluated by walking their events in order, supporting the
+// introduced→fixed→introduced re-introduction pattern. Per the OSV spec,
+// `fixed` is an exclusive upper bound, `last_affected` is inclusive, and an
+// `introduced: "0"` range without an upper bound matches every version.
+func (a *Affected) IsVersionAffected(version string) (bool, error) {
+	if version == "" {
+		return false, fmt.Errorf("empty version")
+	}
+
+	// The explicit version list is authoritative when it matches
+	for _, listed := range a.Versions {
+		if CompareVersions(version, listed) == 0 {
+			return true, nil
+		}
+	}
+
+	matchedAnyRange := false
+	for _, r := range a.Ranges {
+		switch r.Type {
+		case "SEMVER", "ECOSYSTEM":
+		default:
+			// GIT and other range types cannot be evaluated against a
+			// version string
+			continue
+		}
+		matchedAnyRange = true
+
+		affected := false
+		for _, event := range r.Events {
+			switch {
+			case event.Introduced != "":
+				if event.Introduced == "0" || CompareVersions(version, event.Introduced) >= 0 {
+					affected = true
+				}
+			case event.Fixed != "":
+				if affected && CompareVersions(version, event.Fixed) >= 0 {
+					affected = false
+				}
+			case event.Last_affected != "":
+				if affected && CompareVersions(version, event.Last_affected) > 0 {
+					affected = false
+				}
+			}
+		}
+		if affected {
+			return true, nil
+		}
+	}
+
+	if !matchedAnyRange && len(a.Versions) == 0 {
+		return false, fmt.Errorf("no evaluable ranges or versions on affected entry")
+	}
+	return false, nil
+}
@@ -0,0 +1,104 @@
+package knowledge
+
+import "testing"
+
+func TestIsVersionAffectedSemverRange(t *testing.T) {
+	affected := Affected{
+		Ranges: []Range{{
+			Type: "SEMVER",
+			Events: []Event{
+				{Introduced: "1.0.0"},
+				{Fixed: "2.0.0"},
+			},
+		}},
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"0.9.0", false},
+		{"1.0.0", true},
+		{"1.5.0", true},
+		{"2.0.0", false}, // fixed is exclusive
+		{"2.1.0", false},
+	}
+	for _, c := range cases {
+		got, err := affected.IsVersionAffected(c.version)
+		if err != nil {
+			t.Fatalf("IsVersionAffected(%q) returned error: %v", c.version, err)
+		}
+		if got != c.want {
+			t.Errorf("IsVersionAffected(%q) = %v, want %v", c.version, got, c.want)
+		}
+	}
+}
+
+func TestIsVersionAffectedReintroduction(t *testing.T) {
+	affected := Affected{
+		Ranges: []Range{{
+			Type: "ECOSYSTEM",
+			Events: []Event{
+				{Introduced: "1.0.0"},
+				{Fixed: "2.0.0"},
+				{Introduced: "3.0.0"},
+				{Fixed: "4.0.0"},
+			},
+		}},
+	}
+
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"1.5.0", true},
+		{"2.5.0", false},
+		{"3.5.0", true},
+		{"4.0.0", false},
+	}
+	for _, c := range cases {
+		got, err := affected.IsVersionAffected(c.version)
+		if err != nil {
+			t.Fatalf("IsVersionAffected(%q) returned error: %v", c.version, err)
+		}
+		if got != c.want {
+			t.Errorf("IsVersionAffected(%q) = %v, want %v", c.version, got, c.want)
+		}
+	}
+}
+
+func TestIsVersionAffectedEdgeCases(t *testing.T) {
+	// introduced "0" without an upper bound affects every version
+	all := Affected{
+		Ranges: []Range{{Type: "SEMVER", Events: []Event{{Introduced: "0"}}}},
+	}
+	if got, _ := all.IsVersionAffected("99.99.99"); !got {
+		t.Errorf("introduced 0 without fixed should affect every version")
+	}
+
+	// last_affected is inclusive
+	inclusive := Affected{
+		Ranges: []Range{{
+			Type:   "SEMVER",
+			Events: []Event{{Introduced: "1.0.0"}, {Last_affected: "1.2.0"}},
+		}},
+	}
+	if got, _ := inclusive.IsVersionAffected("1.2.0"); !got {
+		t.Errorf("last_affected should be inclusive")
+	}
+	if got, _ := inclusive.IsVersionAffected("1.2.1"); got {
+		t.Errorf("version above last_affected should not be affected")
+	}
+
+	// explicit version list matches even without ranges
+	listed := Affected{Versions: []string{"1.4.2"}}
+	if got, _ := listed.IsVersionAffected("1.4.2"); !got {
+		t.Errorf("explicit version list should match")
+	}
+
+	// only a GIT range and no versions cannot be evaluated
+	git := Affected{Ranges: []Range{{Type: "GIT", Events: []Event{{Introduced: "abc123"}}}}}
+	if _, err := git.IsVersionAffected("1.0.0"); err == nil {
+		t.Errorf("expected error for unevaluable GIT-only range")
+	}
+}
@@ -18,6 +18,6 @@ type PackageVulnerability struct {
 	OsvId            *uuid.UUID `bun:"osv_id,type:uuid"`          // FK to osv.id
 	NvdId            *uuid.UUID `bun:"nvd_id,type:uuid"`          // FK to nvd.id
 	FriendsOfPhpId   *uuid.UUID `bun:"friendsofphp_id,type:uuid"` // FK to friends_of_php.id
-	GcveId           *uuid.UUID `bun:"gcve_id,type:uuid"`          // FK to gcve.id
+	GcveId           *uuid.UUID `bun:"gcve_id,type:uuid"`         // FK to gcve.id
 	CreatedAt        time.Time  `bun:"created_at,default:current_timestamp"`
 }
@@ -0,0 +1,82 @@
+package knowledge
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PackageRef identifies a package of an SBOM for advisory prefetching
+type PackageRef struct {
+	Ecosystem string
+	Name      string
+}
+
+// PrefetchAdvisories fetches the OSV advisories for all packages of an SBOM
+// in two batched queries — one over the package_vulnerability link table and
+// one over the osv table — and returns them keyed by package name for
+// in-memory matching. This replaces the per-package N+1 query pattern, which
+// dominates the runtime of vuln-finding on large projects.
+func PrefetchAdvisories(ctx context.Context, db *bun.DB, pkgs []PackageRef) (map[string][]OSVItem, error) {
+	advisories := make(map[string][]OSVItem)
+	if len(pkgs) == 0 {
+		return advisories, nil
+	}
+
+	var links []PackageVulnerability
+	err := db.NewSelect().
+		Model(&links).
+		Where("osv_id IS NOT NULL").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, pkg := range pkgs {
+				pkg := pkg
+				q = q.WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("package_ecosystem = ?", pkg.Ecosystem).
+						Where("package_name = ?", pkg.Name)
+				})
+			}
+			return q
+		}).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return advisories, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(links))
+	seen := make(map[uuid.UUID]bool, len(links))
+	for _, link := range links {
+		if link.OsvId != nil && !seen[*link.OsvId] {
+			seen[*link.OsvId] = true
+			ids = append(ids, *link.OsvId)
+		}
+	}
+
+	var items []OSVItem
+	err = db.NewSelect().
+		Model(&items).
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsById := make(map[uuid.UUID]OSVItem, len(items))
+	for _, item := range items {
+		itemsById[item.Id] = item
+	}
+
+	for _, link := range links {
+		if link.OsvId == nil {
+			continue
+		}
+		if item, exists := itemsById[*link.OsvId]; exists {
+			advisories[link.PackageName] = append(advisories[link.PackageName], item)
+		}
+	}
+	return advisories, nil
+}
@@ -0,0 +1,156 @@
+package knowledge
+
+// ReconcileCVE merges an NVD record and a GCVE record for the same CVE into a
+// single UnifiedVuln. Precedence is deterministic: the analyst-reviewed NVD
+// CVSS and description win when both feeds carry them, and GCVE (including
+// its ADP enrichments) supplements whatever NVD is missing. Either argument
+// may be nil when only one feed knows the CVE.
+func ReconcileCVE(nvd *NVDItem, gcve *GCVEItem) UnifiedVuln {
+	vuln := UnifiedVuln{
+		FixedVersions: map[string][]string{},
+	}
+
+	if nvd != nil {
+		vuln.VulnerabilityId = nvd.NVDId
+		vuln.CVE = nvd.NVDId
+		vuln.Summary = englishDescription(nvd.Descriptions)
+		vuln.Published = nvd.Published
+		vuln.Modified = nvd.LastModified
+		vuln.Sources = append(vuln.Sources, "nvd")
+
+		if score, severity, vector, _ := nvdBestCvss(nvd); severity != "" || score > 0 {
+			vuln.SeverityScore = score
+			vuln.Severity = severity
+			vuln.SeverityVector = vector
+		}
+		for _, weakness := range nvd.Weaknesses {
+			for _, description := range weakness.Descriptions {
+				vuln.Cwes = appendUnique(vuln.Cwes, description.Value)
+			}
+		}
+	}
+
+	if gcve != nil {
+		if vuln.CVE == "" {
+			vuln.VulnerabilityId = gcve.CVEId
+			vuln.CVE = gcve.CVEId
+		}
+		if vuln.VulnerabilityId == "" {
+			vuln.VulnerabilityId = gcve.GCVEId
+		}
+		vuln.Aliases = appendUnique(vuln.Aliases, gcve.GCVEId)
+		vuln.Sources = append(vuln.Sources, "gcve")
+
+		if vuln.Summary == "" {
+			for _, description := range gcve.Descriptions {
+				if description.Lang == "en" || vuln.Summary == "" {
+					vuln.Summary = description.Value
+					if description.Lang == "en" {
+						break
+					}
+				}
+			}
+		}
+		if vuln.Published == "" {
+			vuln.Published = gcve.DatePublished
+		}
+		if vuln.Modified == "" {
+			vuln.Modified = gcve.DateUpdated
+		}
+		// NVD CVSS is authoritative; GCVE (CNA or ADP) only fills a gap
+		if vuln.Severity == "" && vuln.SeverityScore == 0 {
+			if cvss := gcveBestCvss(gcve); cvss != nil {
+				vuln.SeverityScore = cvss.BaseScore
+				vuln.Severity = cvss.BaseSeverity
+				vuln.SeverityVector = cvss.VectorString
+			}
+		}
+		for _, id := range gcve.CweIDs() {
+			vuln.Cwes = appendUnique(vuln.Cwes, id)
+		}
+	}
+
+	return vuln
+}
+
+// englishDescription returns the English description from an NVD description
+// list, falling back to the first entry
+func englishDescription(descriptions []Descriptions) string {
+	for _, description := range descriptions {
+		if description.Lang == "en" {
+			return description.Value
+		}
+	}
+	if len(descriptions) > 0 {
+		return descriptions[0].Value
+	}
+	return ""
+}
+
+// nvdBestCvss returns the best available CVSS data of an NVD item, preferring
+// the primary v3.1 entry, then v3.0, then v2
+func nvdBestCvss(nvd *NVDItem) (float64, string, string, string) {
+	if metric, exists := nvd.Metrics.PrimaryV31(); exists {
+		return metric.CvssData.BaseScore, metric.CvssData.BaseSeverity, metric.CvssData.VectorString, "3.1"
+	}
+	if len(nvd.Metrics.CvssMetricV30) > 0 {
+		data := nvd.Metrics.CvssMetricV30[0].CvssData
+		return data.BaseScore, data.BaseSeverity, data.VectorString, "3.0"
+	}
+	if len(nvd.Metrics.CvssMetricV2) > 0 {
+		metric := nvd.Metrics.CvssMetricV2[0]
+		return metric.CvssData.BaseScore, metric.BaseSeverity, metric.CvssData.VectorString, "2.0"
+	}
+	return 0, "", "", ""
+}
+
+// gcveBestCvss returns the best available CVSS score of a GCVE item, checking
+// the CNA metrics first and the ADP enrichments after, preferring v3.1 over
+// v3.0 over v4.0 over v2
+func gcveBestCvss(gcve *GCVEItem) *GCVECvssScore {
+	pick := func(entries []GCVEMetricEntry) *GCVECvssScore {
+		for _, entry := range entries {
+			if entry.CvssV31 != nil {
+				return entry.CvssV31
+			}
+		}
+		for _, entry := range entries {
+			if entry.CvssV30 != nil {
+				return entry.CvssV30
+			}
+		}
+		for _, entry := range entries {
+			if entry.CvssV40 != nil {
+				return entry.CvssV40
+			}
+		}
+		for _, entry := range entries {
+			if entry.CvssV2 != nil {
+				return entry.CvssV2
+			}
+		}
+		return nil
+	}
+
+	if cvss := pick(gcve.Metrics); cvss != nil {
+		return cvss
+	}
+	for _, adp := range gcve.ADPEnrichments {
+		if cvss := pick(adp.Metrics); cvss != nil {
+			return cvss
+		}
+	}
+	return nil
+}
+
+func appendUnique(list []string, value string) []string {
+	if value == "" {
+		return list
+	}
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,58 @@
+package knowledge
+
+// SeverityProvider is implemented by all vulnerability source types so
+// consumers (e.g. a vuln-finder plugin) can read severity uniformly without
+// caring how each feed represents its CVSS data
+type SeverityProvider interface {
+	// BestSeverity returns the richest available severity data: numeric
+	// score, qualitative severity, vector string and CVSS version. When no
+	// CVSS data exists all values are zero/empty.
+	BestSeverity() (score float64, severity string, vector string, version string)
+}
+
+// BestSeverity implements SeverityProvider for NVD items, preferring the
+// highest CVSS version with a primary source
+func (n *NVDItem) BestSeverity() (float64, string, string, string) {
+	score, severity, vector, version, exists := n.Metrics.BestCVSS()
+	if !exists {
+		return 0, "", "", ""
+	}
+	return score, severity, vector, version
+}
+
+// BestSeverity implements SeverityProvider for OSV items. OSV only carries a
+// vector string, so the numeric score is derived by parsing it.
+func (o *OSVItem) BestSeverity() (float64, string, string, string) {
+	// Prefer CVSS v3 entries, which we can fully score
+	for _, severity := range o.Severity {
+		if severity.Type != "CVSS_V3" {
+			continue
+		}
+		if cvss, err := ParseCVSS3Vector(severity.Score); err == nil {
+			score := cvss.ComputeBaseScore()
+			return score, SeverityFromScore(score), severity.Score, cvss.Version
+		}
+	}
+	// Fall back to any other entry, keeping the vector without a score
+	for _, severity := range o.Severity {
+		version := ""
+		switch severity.Type {
+		case "CVSS_V2":
+			version = "2.0"
+		case "CVSS_V4":
+			version = "4.0"
+		}
+		return 0, "", severity.Score, version
+	}
+	return 0, "", "", ""
+}
+
+// BestSeverity implements SeverityProvider for GCVE items, checking the CNA
+// metrics before the ADP enrichments
+func (g *GCVEItem) BestSeverity() (float64, string, string, string) {
+	cvss := gcveBestCvss(g)
+	if cvss == nil {
+		return 0, "", "", ""
+	}
+	return cvss.BaseScore, cvss.BaseSeverity, cvss.VectorString, cvss.Version
+}
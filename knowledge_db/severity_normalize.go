@@ -0,0 +1,85 @@
+package knowledge
+
+// NormalizedSeverity is the source-independent severity view consumers
+// display: base score, qualitative severity, the CVSS version it came from
+// and the raw vector
+type NormalizedSeverity struct {
+	Score    float64 `json:"score"`
+	Severity string  `json:"severity"`
+	Version  string  `json:"version"`
+	Vector   string  `json:"vector"`
+}
+
+// NormalizeSeverity extracts the best severity data from an NVD, OSV or
+// GCVE item, preferring CVSS v3.1 over v3.0 over v4.0 over v2. When no CVSS
+// data exists it falls back to the ML-predicted Vlai score where the source
+// carries one; v2 metrics lack a BaseSeverity, so the severity is derived
+// from the score bands.
+func NormalizeSeverity(item any) NormalizedSeverity {
+	switch typed := item.(type) {
+	case *NVDItem:
+		return normalizeNVDSeverity(typed)
+	case NVDItem:
+		return normalizeNVDSeverity(&typed)
+	case *OSVItem:
+		return normalizeOSVSeverity(typed)
+	case OSVItem:
+		return normalizeOSVSeverity(&typed)
+	case *GCVEItem:
+		return normalizeGCVESeverity(typed)
+	case GCVEItem:
+		return normalizeGCVESeverity(&typed)
+	default:
+		return NormalizedSeverity{}
+	}
+}
+
+func normalizeNVDSeverity(item *NVDItem) NormalizedSeverity {
+	if metric, exists := item.Metrics.PrimaryV31(); exists {
+		data := metric.CvssData
+		return NormalizedSeverity{Score: data.BaseScore, Severity: data.BaseSeverity, Version: "3.1", Vector: data.VectorString}
+	}
+	if len(item.Metrics.CvssMetricV30) > 0 {
+		data := item.Metrics.CvssMetricV30[0].CvssData
+		return NormalizedSeverity{Score: data.BaseScore, Severity: data.BaseSeverity, Version: "3.0", Vector: data.VectorString}
+	}
+	if len(item.Metrics.CvssMetricV40) > 0 {
+		data := item.Metrics.CvssMetricV40[0].CvssData
+		return NormalizedSeverity{Score: data.BaseScore, Severity: data.BaseSeverity, Version: "4.0", Vector: data.VectorString}
+	}
+	if len(item.Metrics.CvssMetricV2) > 0 {
+		metric := item.Metrics.CvssMetricV2[0]
+		severity := metric.BaseSeverity
+		if severity == "" {
+			severity = SeverityFromScore(metric.CvssData.BaseScore)
+		}
+		return NormalizedSeverity{Score: metric.CvssData.BaseScore, Severity: severity, Version: "2.0", Vector: metric.CvssData.VectorString}
+	}
+	if item.Vlai_score != "" {
+		return NormalizedSeverity{Severity: item.Vlai_score}
+	}
+	return NormalizedSeverity{}
+}
+
+func normalizeOSVSeverity(item *OSVItem) NormalizedSeverity {
+	score, severity, vector, version := item.BestSeverity()
+	if vector != "" || severity != "" {
+		return NormalizedSeverity{Score: score, Severity: severity, Version: version, Vector: vector}
+	}
+	if item.Vlai_score != "" {
+		return NormalizedSeverity{Severity: item.Vlai_score}
+	}
+	return NormalizedSeverity{}
+}
+
+func normalizeGCVESeverity(item *GCVEItem) NormalizedSeverity {
+	cvss := gcveBestCvss(item)
+	if cvss == nil {
+		return NormalizedSeverity{}
+	}
+	severity := cvss.BaseSeverity
+	if severity == "" {
+		severity = SeverityFromScore(cvss.BaseScore)
+	}
+	return NormalizedSeverity{Score: cvss.BaseScore, Severity: severity, Version: cvss.Version, Vector: cvss.VectorString}
+}
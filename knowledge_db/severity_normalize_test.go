@@ -0,0 +1,128 @@
+package knowledge
+
+import "testing"
+
+func TestNormalizeSeverityNVD(t *testing.T) {
+	cases := []struct {
+		name     string
+		item     NVDItem
+		expected NormalizedSeverity
+	}{
+		{
+			name: "prefers v3.1 over v4.0",
+			item: NVDItem{
+				Metrics: Metrics{
+					CvssMetricV40: []ImpactMetricV40{{
+						CvssData: CVSS40{BaseScore: 8.7, BaseSeverity: "HIGH", VectorString: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:N/SC:N/SI:N/SA:N"},
+					}},
+					CvssMetricV31: []ImpactMetricV31{{
+						Type:     "Primary",
+						CvssData: CVSS3{BaseScore: 9.8, BaseSeverity: "CRITICAL", VectorString: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+					}},
+				},
+			},
+			expected: NormalizedSeverity{Score: 9.8, Severity: "CRITICAL", Version: "3.1", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		},
+		{
+			name: "prefers v3.0 over v4.0",
+			item: NVDItem{
+				Metrics: Metrics{
+					CvssMetricV40: []ImpactMetricV40{{
+						CvssData: CVSS40{BaseScore: 8.7, BaseSeverity: "HIGH", VectorString: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:N/SC:N/SI:N/SA:N"},
+					}},
+					CvssMetricV30: []ImpactMetricV30{{
+						CvssData: CVSS3{BaseScore: 7.5, BaseSeverity: "HIGH", VectorString: "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"},
+					}},
+				},
+			},
+			expected: NormalizedSeverity{Score: 7.5, Severity: "HIGH", Version: "3.0", Vector: "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N"},
+		},
+		{
+			name: "v4.0 when no v3 data",
+			item: NVDItem{
+				Metrics: Metrics{
+					CvssMetricV40: []ImpactMetricV40{{
+						CvssData: CVSS40{BaseScore: 8.7, BaseSeverity: "HIGH", VectorString: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:N/SC:N/SI:N/SA:N"},
+					}},
+				},
+			},
+			expected: NormalizedSeverity{Score: 8.7, Severity: "HIGH", Version: "4.0", Vector: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:N/SC:N/SI:N/SA:N"},
+		},
+		{
+			name: "v2 severity derived from score bands",
+			item: NVDItem{
+				Metrics: Metrics{
+					CvssMetricV2: []ImpactMetricV2{{
+						CvssData: CVSSV2{BaseScore: 5.0, VectorString: "AV:N/AC:L/Au:N/C:P/I:N/A:N"},
+					}},
+				},
+			},
+			expected: NormalizedSeverity{Score: 5.0, Severity: "MEDIUM", Version: "2.0", Vector: "AV:N/AC:L/Au:N/C:P/I:N/A:N"},
+		},
+		{
+			name: "v2 keeps metric-level severity when present",
+			item: NVDItem{
+				Metrics: Metrics{
+					CvssMetricV2: []ImpactMetricV2{{
+						BaseSeverity: "MEDIUM",
+						CvssData:     CVSSV2{BaseScore: 6.8, VectorString: "AV:N/AC:M/Au:N/C:P/I:P/A:P"},
+					}},
+				},
+			},
+			expected: NormalizedSeverity{Score: 6.8, Severity: "MEDIUM", Version: "2.0", Vector: "AV:N/AC:M/Au:N/C:P/I:P/A:P"},
+		},
+		{
+			name:     "vlai fallback when no metrics",
+			item:     NVDItem{Vlai_score: "HIGH"},
+			expected: NormalizedSeverity{Severity: "HIGH"},
+		},
+		{
+			name:     "empty item",
+			item:     NVDItem{},
+			expected: NormalizedSeverity{},
+		},
+	}
+
+	for _, testCase := range cases {
+		normalized := NormalizeSeverity(&testCase.item)
+		if normalized != testCase.expected {
+			t.Errorf("%s: NormalizeSeverity = %+v, want %+v", testCase.name, normalized, testCase.expected)
+		}
+	}
+}
+
+func TestNormalizeSeverityOSV(t *testing.T) {
+	withVector := OSVItem{
+		Severity: []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}},
+	}
+	normalized := NormalizeSeverity(&withVector)
+	if normalized.Score != 9.8 || normalized.Severity != "CRITICAL" || normalized.Version != "3.1" {
+		t.Errorf("OSV CVSS_V3: NormalizeSeverity = %+v", normalized)
+	}
+
+	withVlai := OSVItem{Vlai_score: "MEDIUM"}
+	normalized = NormalizeSeverity(&withVlai)
+	if normalized.Severity != "MEDIUM" || normalized.Score != 0 || normalized.Vector != "" {
+		t.Errorf("OSV vlai fallback: NormalizeSeverity = %+v", normalized)
+	}
+}
+
+func TestNormalizeSeverityGCVE(t *testing.T) {
+	item := GCVEItem{
+		Metrics: []GCVEMetricEntry{{
+			CvssV2: &GCVECvssScore{Version: "2.0", BaseScore: 7.5, VectorString: "AV:N/AC:L/Au:N/C:P/I:P/A:P"},
+		}},
+	}
+	normalized := NormalizeSeverity(&item)
+	// GCVE v2 scores lack a BaseSeverity, so it is derived from the bands
+	expected := NormalizedSeverity{Score: 7.5, Severity: "HIGH", Version: "2.0", Vector: "AV:N/AC:L/Au:N/C:P/I:P/A:P"}
+	if normalized != expected {
+		t.Errorf("GCVE v2: NormalizeSeverity = %+v, want %+v", normalized, expected)
+	}
+}
+
+func TestNormalizeSeverityUnknownType(t *testing.T) {
+	if normalized := NormalizeSeverity("not a vuln item"); normalized != (NormalizedSeverity{}) {
+		t.Errorf("unknown type: NormalizeSeverity = %+v, want zero value", normalized)
+	}
+}
@@ -0,0 +1,25 @@
+package knowledge
+
+// UnifiedVuln is a source-independent view of a vulnerability, used when
+// combining data from the NVD, OSV, GCVE and FriendsOfPHP feeds into a
+// single entry per CVE/advisory
+type UnifiedVuln struct {
+	VulnerabilityId string   `json:"vulnerability_id"`
+	CVE             string   `json:"cve,omitempty"`
+	Aliases         []string `json:"aliases,omitempty"`
+	Sources         []string `json:"sources,omitempty"`
+	Summary         string   `json:"summary,omitempty"`
+	Details         string   `json:"details,omitempty"`
+	Severity        string   `json:"severity,omitempty"`
+	SeverityScore   float64  `json:"severity_score,omitempty"`
+	SeverityVector  string   `json:"severity_vector,omitempty"`
+	Published       string   `json:"published,omitempty"`
+	Modified        string   `json:"modified,omitempty"`
+	Cwes            []string `json:"cwes,omitempty"`
+	// AffectedPackages lists the affected package names, qualified with
+	// their ecosystem where known (e.g. "npm:lodash")
+	AffectedPackages []string `json:"affected_packages,omitempty"`
+	// FixedVersions maps a source name (e.g. "osv", "nvd") to the versions
+	// that source reports as fixing the vulnerability
+	FixedVersions map[string][]string `json:"fixed_versions,omitempty"`
+}
@@ -0,0 +1,150 @@
+package knowledge
+
+import (
+	"sort"
+	"strings"
+)
+
+// DepNode is a node of a resolved dependency graph, used to plan upgrades
+// across a whole dependency set
+type DepNode struct {
+	Name     string     `json:"name"`
+	Version  string     `json:"version"`
+	Children []*DepNode `json:"children,omitempty"`
+}
+
+// PlannedUpgrade is a single upgrade of the consolidated plan together with
+// the vulnerabilities it resolves
+type PlannedUpgrade struct {
+	PackageName             string   `json:"package_name"`
+	FromVersion             string   `json:"from_version"`
+	ToVersion               string   `json:"to_version"`
+	BumpType                string   `json:"bump_type"`
+	ResolvesVulnerabilities []string `json:"resolves_vulnerabilities"`
+}
+
+// UpgradePlan is a consolidated set of upgrades resolving as many findings as
+// possible with minimal version bumps
+type UpgradePlan struct {
+	Upgrades []PlannedUpgrade `json:"upgrades"`
+	// Unresolved lists the vulnerability ids no upgrade could resolve,
+	// either because no affected package is in the graph or because no
+	// fixed version is known
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// PlanUpgrades computes a consolidated upgrade plan for a set of findings
+// against a dependency graph. Vulnerabilities sharing an affected package are
+// resolved by a single upgrade to the lowest version fixing all of them, so
+// shared transitive dependencies are only bumped once. Upgrades are ordered
+// by the number of vulnerabilities they resolve.
+func PlanUpgrades(findings []UnifiedVuln, graph *DepNode) UpgradePlan {
+	installed := collectInstalledVersions(graph)
+
+	// Group findings by the affected packages present in the graph
+	type packagePlan struct {
+		target string
+		vulns  []string
+	}
+	plans := make(map[string]*packagePlan)
+	var unresolved []string
+
+	for _, vuln := range findings {
+		resolved := false
+		for _, affected := range vuln.AffectedPackages {
+			name := stripEcosystemPrefix(affected)
+			current, exists := installed[name]
+			if !exists {
+				continue
+			}
+			target := minimalFixAbove(vuln, current)
+			if target == "" {
+				continue
+			}
+
+			plan, exists := plans[name]
+			if !exists {
+				plan = &packagePlan{target: target}
+				plans[name] = plan
+			} else if CompareVersions(target, plan.target) > 0 {
+				// One upgrade has to satisfy every vulnerability of
+				// the package, so the highest required fix wins
+				plan.target = target
+			}
+			plan.vulns = append(plan.vulns, vuln.VulnerabilityId)
+			resolved = true
+		}
+		if !resolved {
+			unresolved = append(unresolved, vuln.VulnerabilityId)
+		}
+	}
+
+	var upgrades []PlannedUpgrade
+	for name, plan := range plans {
+		upgrades = append(upgrades, PlannedUpgrade{
+			PackageName:             name,
+			FromVersion:             installed[name],
+			ToVersion:               plan.target,
+			BumpType:                bumpType(installed[name], plan.target),
+			ResolvesVulnerabilities: plan.vulns,
+		})
+	}
+	sort.Slice(upgrades, func(i, j int) bool {
+		if len(upgrades[i].ResolvesVulnerabilities) != len(upgrades[j].ResolvesVulnerabilities) {
+			return len(upgrades[i].ResolvesVulnerabilities) > len(upgrades[j].ResolvesVulnerabilities)
+		}
+		return upgrades[i].PackageName < upgrades[j].PackageName
+	})
+
+	return UpgradePlan{
+		Upgrades:   upgrades,
+		Unresolved: unresolved,
+	}
+}
+
+// collectInstalledVersions walks the graph and returns the installed version
+// per package name
+func collectInstalledVersions(graph *DepNode) map[string]string {
+	versions := make(map[string]string)
+	var walk func(node *DepNode)
+	walk = func(node *DepNode) {
+		if node == nil {
+			return
+		}
+		if node.Name != "" {
+			if _, seen := versions[node.Name]; !seen {
+				versions[node.Name] = node.Version
+			}
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(graph)
+	return versions
+}
+
+// minimalFixAbove returns the lowest fixed version of the vulnerability that
+// is above the currently installed version, or an empty string when none is
+// known
+func minimalFixAbove(vuln UnifiedVuln, current string) string {
+	var best string
+	for _, versions := range vuln.FixedVersions {
+		for _, fixed := range versions {
+			if fixed == "" || CompareVersions(fixed, current) <= 0 {
+				continue
+			}
+			if best == "" || CompareVersions(fixed, best) < 0 {
+				best = fixed
+			}
+		}
+	}
+	return best
+}
+
+func stripEcosystemPrefix(name string) string {
+	if idx := strings.Index(name, ":"); idx != -1 {
+		return name[idx+1:]
+	}
+	return name
+}
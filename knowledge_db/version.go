@@ -0,0 +1,208 @@
+package knowledge
+
+import (
+	"strconv"
+	"strings"
+)
+
+// stabilityRank orders well-known pre-release stability words so that
+// e.g. 1.0.0-dev < 1.0.0-alpha < 1.0.0-beta < 1.0.0-RC1 < 1.0.0
+var stabilityRank = map[string]int{
+	"dev":   0,
+	"a":     1,
+	"alpha": 1,
+	"b":     2,
+	"beta":  2,
+	"rc":    3,
+}
+
+// CompareVersions compares two version strings and returns -1, 0 or 1 when a
+// is lower than, equal to or higher than b. Numeric dot-separated segments are
+// compared numerically, a missing segment counts as zero, and pre-release
+// suffixes (e.g. -beta, -RC1) sort before the corresponding release.
+func CompareVersions(a string, b string) int {
+	aRelease, aPre := splitVersion(a)
+	bRelease, bPre := splitVersion(b)
+
+	if cmp := compareSegments(aRelease, bRelease); cmp != 0 {
+		return cmp
+	}
+
+	// Same release: no pre-release suffix wins over any pre-release
+	if aPre == "" && bPre == "" {
+		return 0
+	}
+	if aPre == "" {
+		return 1
+	}
+	if bPre == "" {
+		return -1
+	}
+	return comparePreRelease(aPre, bPre)
+}
+
+// splitVersion normalizes a version string and splits it into the release
+// part and an optional pre-release suffix. Build metadata (after +) is ignored.
+func splitVersion(version string) (string, string) {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "v")
+	version = strings.TrimPrefix(version, "V")
+	if idx := strings.Index(version, "+"); idx != -1 {
+		version = version[:idx]
+	}
+	if idx := strings.Index(version, "-"); idx != -1 {
+		return version[:idx], version[idx+1:]
+	}
+	return version, ""
+}
+
+func compareSegments(a string, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	length := len(aParts)
+	if len(bParts) > length {
+		length = len(bParts)
+	}
+
+	for i := 0; i < length; i++ {
+		aPart := "0"
+		bPart := "0"
+		if i < len(aParts) && aParts[i] != "" {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) && bParts[i] != "" {
+			bPart = bParts[i]
+		}
+
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		if aErr == nil && bErr == nil {
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		// Fall back to a lexical comparison for non-numeric segments
+		if cmp := strings.Compare(aPart, bPart); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+func comparePreRelease(a string, b string) int {
+	aParts := splitPreRelease(a)
+	bParts := splitPreRelease(b)
+
+	length := len(aParts)
+	if len(bParts) > length {
+		length = len(bParts)
+	}
+
+	for i := 0; i < length; i++ {
+		// A shorter pre-release sorts first (1.0-rc < 1.0-rc.1)
+		if i >= len(aParts) {
+			return -1
+		}
+		if i >= len(bParts) {
+			return 1
+		}
+
+		aPart := aParts[i]
+		bPart := bParts[i]
+
+		aRank, aKnown := stabilityRank[strings.ToLower(aPart)]
+		bRank, bKnown := stabilityRank[strings.ToLower(bPart)]
+		if aKnown && bKnown {
+			if aRank != bRank {
+				if aRank < bRank {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		if aErr == nil && bErr == nil {
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		// Numeric identifiers sort before alphanumeric ones
+		if aErr == nil {
+			return -1
+		}
+		if bErr == nil {
+			return 1
+		}
+		if cmp := strings.Compare(strings.ToLower(aPart), strings.ToLower(bPart)); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// splitPreRelease splits a pre-release suffix like "RC1" or "beta.2" into
+// alternating alphabetic and numeric identifiers for comparison
+func splitPreRelease(pre string) []string {
+	var parts []string
+	var current []rune
+	var currentIsDigit bool
+
+	flush := func() {
+		if len(current) > 0 {
+			parts = append(parts, string(current))
+			current = nil
+		}
+	}
+
+	for _, char := range pre {
+		switch {
+		case char == '.' || char == '-' || char == '_':
+			flush()
+		case char >= '0' && char <= '9':
+			if !currentIsDigit {
+				flush()
+			}
+			currentIsDigit = true
+			current = append(current, char)
+		default:
+			if currentIsDigit {
+				flush()
+			}
+			currentIsDigit = false
+			current = append(current, char)
+		}
+	}
+	flush()
+	return parts
+}
+
+// MajorMinorPatch extracts the numeric major, minor and patch components of a
+// version, treating missing or non-numeric segments as zero
+func MajorMinorPatch(version string) (int, int, int) {
+	release, _ := splitVersion(version)
+	parts := strings.Split(release, ".")
+
+	get := func(i int) int {
+		if i >= len(parts) {
+			return 0
+		}
+		num, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0
+		}
+		return num
+	}
+	return get(0), get(1), get(2)
+}
@@ -0,0 +1,45 @@
+package knowledge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// ComputeVulnKey derives a stable deduplication key for a unified
+// vulnerability. A CVE id always wins, so sources that agree on the CVE map
+// to the same key regardless of their primary ids. Without one the
+// lexicographically smallest of the vulnerability's own identifiers is used
+// — sources collapse only when their identifier sets agree on that smallest
+// element, not on any shared alias; full alias-set unification needs a view
+// across all sources and is out of scope here. A vulnerability without
+// identifiers falls back to a hash over its affected packages.
+func ComputeVulnKey(v UnifiedVuln) string {
+	identifiers := make([]string, 0, len(v.Aliases)+2)
+	if v.CVE != "" {
+		identifiers = append(identifiers, v.CVE)
+	}
+	if v.VulnerabilityId != "" {
+		identifiers = append(identifiers, v.VulnerabilityId)
+	}
+	identifiers = append(identifiers, v.Aliases...)
+
+	for _, id := range identifiers {
+		if strings.HasPrefix(id, "CVE-") {
+			return id
+		}
+	}
+
+	if len(identifiers) > 0 {
+		sort.Strings(identifiers)
+		return identifiers[0]
+	}
+
+	packages := make([]string, len(v.AffectedPackages))
+	copy(packages, v.AffectedPackages)
+	sort.Strings(packages)
+
+	hash := sha256.Sum256([]byte(strings.Join(packages, "\n") + "\x00" + v.Summary))
+	return "VULN-" + hex.EncodeToString(hash[:8])
+}
@@ -0,0 +1,60 @@
+package knowledge
+
+import "testing"
+
+func TestComputeVulnKeyPrefersCVE(t *testing.T) {
+	fromOSV := UnifiedVuln{
+		VulnerabilityId: "GHSA-jf85-cpcp-j695",
+		Aliases:         []string{"CVE-2019-10744"},
+	}
+	fromNVD := UnifiedVuln{
+		VulnerabilityId: "CVE-2019-10744",
+		CVE:             "CVE-2019-10744",
+	}
+
+	if key := ComputeVulnKey(fromOSV); key != "CVE-2019-10744" {
+		t.Errorf("ComputeVulnKey(osv) = %q, want CVE-2019-10744", key)
+	}
+	if ComputeVulnKey(fromOSV) != ComputeVulnKey(fromNVD) {
+		t.Errorf("sources agreeing on the CVE should map to the same key")
+	}
+}
+
+func TestComputeVulnKeyWithoutCVE(t *testing.T) {
+	vuln := UnifiedVuln{
+		VulnerabilityId: "PYSEC-2021-123",
+		Aliases:         []string{"GHSA-jf85-cpcp-j695"},
+	}
+	// The lexicographically smallest of the vulnerability's own identifiers
+	if key := ComputeVulnKey(vuln); key != "GHSA-jf85-cpcp-j695" {
+		t.Errorf("ComputeVulnKey = %q, want GHSA-jf85-cpcp-j695", key)
+	}
+}
+
+func TestComputeVulnKeyWithoutIdentifiers(t *testing.T) {
+	vuln := UnifiedVuln{
+		Summary:          "Prototype pollution in lodash",
+		AffectedPackages: []string{"npm:lodash", "npm:lodash-es"},
+	}
+	key := ComputeVulnKey(vuln)
+	if len(key) != len("VULN-")+16 || key[:5] != "VULN-" {
+		t.Errorf("ComputeVulnKey = %q, want a VULN- hash key", key)
+	}
+
+	// The hash is stable regardless of package order
+	reordered := UnifiedVuln{
+		Summary:          "Prototype pollution in lodash",
+		AffectedPackages: []string{"npm:lodash-es", "npm:lodash"},
+	}
+	if ComputeVulnKey(reordered) != key {
+		t.Errorf("hash key is not stable")
+	}
+
+	differentSummary := UnifiedVuln{
+		Summary:          "Command injection in lodash",
+		AffectedPackages: []string{"npm:lodash", "npm:lodash-es"},
+	}
+	if ComputeVulnKey(differentSummary) == key {
+		t.Errorf("distinct vulnerabilities should not share a hash key")
+	}
+}